@@ -0,0 +1,103 @@
+package tfdocextras
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/terraform-docs/terraform-docs/terraform"
+)
+
+// ChangelogEntry is an input or nested field added or deprecated in a release
+type ChangelogEntry struct {
+	Path        string `json:"path"`
+	Description string `json:"description,omitempty"`
+}
+
+// ChangelogRelease lists the inputs and nested fields added and deprecated in a
+// version of a module
+type ChangelogRelease struct {
+	Version    string           `json:"version"`
+	Added      []ChangelogEntry `json:"added,omitempty"`
+	Deprecated []ChangelogEntry `json:"deprecated,omitempty"`
+}
+
+// changelogBuilder groups entries by release
+type changelogBuilder struct {
+	releases map[string]*ChangelogRelease
+	versions map[string]Version
+}
+
+// GenerateChangelog groups the inputs of a module loaded by terraform-docs and
+// their nested fields by the version of their `@since` directive, and by the
+// version their `@deprecated` directive starts with (e.g. `@deprecated 2.1.0
+// Use size instead`). Deprecations without a version are left out, as are
+// directives inherited from a parent, which is listed instead. Releases are
+// ordered from the newest to the oldest.
+func GenerateChangelog(inputs []*terraform.Input) []ChangelogRelease {
+	builder := changelogBuilder{
+		releases: map[string]*ChangelogRelease{},
+		versions: map[string]Version{},
+	}
+
+	for _, input := range inputs {
+		field := inputField(input)
+		builder.walk(input.Name, &field)
+	}
+
+	releases := make([]ChangelogRelease, 0, len(builder.releases))
+	for _, release := range builder.releases {
+		releases = append(releases, *release)
+	}
+
+	slices.SortFunc(releases, func(a, b ChangelogRelease) int {
+		return builder.versions[b.Version].Compare(builder.versions[a.Version])
+	})
+
+	return releases
+}
+
+// walk records the directives of a field, then of its nested fields
+func (b *changelogBuilder) walk(path string, field *ObjectField) {
+	summary := firstLine(strings.Join(field.Documentation.Content, "\n"))
+
+	for _, attr := range field.Documentation.Directives {
+		if (attr.Parsed.Flags & IsValid) == 0 {
+			continue
+		}
+
+		switch attr.Parsed.Type {
+		case DirSince:
+			if version, err := ParseVersion(attr.RawContent); err == nil {
+				release := b.release(version)
+				release.Added = append(release.Added, ChangelogEntry{Path: path, Description: summary})
+			}
+		case DirDeprecated:
+			versionStr, message, _ := strings.Cut(strings.TrimSpace(attr.RawContent), " ")
+			if version, err := ParseVersion(versionStr); err == nil {
+				if message = strings.TrimSpace(message); message == "" {
+					message = summary
+				}
+
+				release := b.release(version)
+				release.Deprecated = append(release.Deprecated, ChangelogEntry{Path: path, Description: message})
+			}
+		}
+	}
+
+	for i := range field.Fields {
+		b.walk(path+"."+field.Fields[i].Name, &field.Fields[i])
+	}
+}
+
+// release returns the release of version, creating it when needed. Versions
+// are normalized, so that `v1.0.0` and `1.0.0` are the same release.
+func (b *changelogBuilder) release(version Version) *ChangelogRelease {
+	key := version.String()
+
+	if _, ok := b.releases[key]; !ok {
+		b.releases[key] = &ChangelogRelease{Version: key}
+		b.versions[key] = version
+	}
+
+	return b.releases[key]
+}
@@ -0,0 +1,38 @@
+package tfdocextras
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestGenerateChangelog(t *testing.T) {
+	module := loadTestModule(t, "changelog")
+
+	expected := []ChangelogRelease{
+		{
+			Version:    "3.0.0",
+			Deprecated: []ChangelogEntry{{Path: "tags", Description: "The tags of the resources."}},
+		},
+		{
+			Version: "2.1.0",
+			Added: []ChangelogEntry{
+				{Path: "node_pool.size", Description: "The size of the pool"},
+				{Path: "tags", Description: "The tags of the resources."},
+			},
+			Deprecated: []ChangelogEntry{{Path: "node_pool.count", Description: "Use size instead"}},
+		},
+		{
+			Version: "1.1.0",
+			Added:   []ChangelogEntry{{Path: "node_pool", Description: "The default node pool."}},
+		},
+		{
+			Version: "1.0.0",
+			Added:   []ChangelogEntry{{Path: "name", Description: "The name of the cluster."}},
+		},
+	}
+
+	if diff := deep.Equal(GenerateChangelog(module.Inputs), expected); diff != nil {
+		t.Errorf("Changelog mismatch:\n%v", diff)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// writeFileAtomic replaces the file at path with content by writing to a
+// temporary file in the same directory and renaming it over the original, so a
+// failure never leaves a partially written file behind. The original file mode
+// is preserved, and when backup is set the original content is first copied to
+// `<path>.bak`.
+func writeFileAtomic(path string, content []byte, backup bool) (err error) {
+	mode := os.FileMode(0644)
+
+	original, statErr := os.Stat(path)
+	if statErr == nil {
+		mode = original.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", tmp.Name(), err)
+	}
+
+	if err = tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", tmp.Name(), err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", tmp.Name(), err)
+	}
+
+	if err = os.Chmod(tmp.Name(), mode); err != nil {
+		return fmt.Errorf("failed to set the mode of %s: %w", tmp.Name(), err)
+	}
+
+	if backup && statErr == nil {
+		if err = copyFile(path, path+".bak", mode); err != nil {
+			return err
+		}
+	}
+
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func copyFile(from, to string, mode os.FileMode) error {
+	content, err := os.ReadFile(from)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", from, err)
+	}
+
+	if err := os.WriteFile(to, content, mode); err != nil {
+		return fmt.Errorf("failed to write backup %s: %w", to, err)
+	}
+
+	return nil
+}
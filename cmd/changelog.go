@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/FriendsOfTerraform/tfdocs-extras"
+)
+
+const ChangelogMarkerStart = "<!-- TFDOCS_EXTRAS_CHANGELOG_START -->"
+const ChangelogMarkerEnd = "<!-- TFDOCS_EXTRAS_CHANGELOG_END -->"
+
+func runChangelog(args []string, stdout, stderr io.Writer) int {
+	var opts renderOptions
+	var output string
+
+	fs := newFlagSet("changelog", "changelog [flags] [module-path]", stderr)
+	fs.StringVar(&output, "output", "-", "insert the changelog between the changelog markers of this file (e.g. CHANGELOG.md), or \"-\" for stdout")
+
+	modulePath, err := parseCommand(fs, args)
+	if err == nil {
+		err = opts.load(fs, modulePath)
+	}
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	module, err := loadModule(modulePath, &opts)
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	changelog := renderChangelog(tfdocextras.GenerateChangelog(module.Inputs))
+
+	if output == "-" {
+		fmt.Fprint(stdout, changelog)
+		return exitOK
+	}
+
+	content, err := os.ReadFile(output)
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	updated, err := replaceContentBetweenMarkers(string(content), ChangelogMarkerStart, ChangelogMarkerEnd, "\n"+strings.TrimSuffix(changelog, "\n"))
+	if err != nil {
+		return exitCodeFor(fmt.Errorf("%s: %w", output, err), stderr)
+	}
+
+	if err := writeFileAtomic(output, []byte(updated), false); err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	fmt.Fprintf(stdout, "%s updated successfully\n", output)
+
+	return exitOK
+}
+
+// renderChangelog renders a markdown section per release, listing the inputs
+// added and deprecated in it
+func renderChangelog(releases []tfdocextras.ChangelogRelease) string {
+	var content strings.Builder
+
+	for _, release := range releases {
+		fmt.Fprintf(&content, "## %s\n\n", release.Version)
+
+		writeChangelogEntries(&content, "Inputs added", release.Added)
+		writeChangelogEntries(&content, "Inputs deprecated", release.Deprecated)
+	}
+
+	return content.String()
+}
+
+func writeChangelogEntries(w io.Writer, title string, entries []tfdocextras.ChangelogEntry) {
+	if len(entries) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "### %s\n\n", title)
+
+	for _, entry := range entries {
+		if entry.Description != "" {
+			fmt.Fprintf(w, "- `%s`: %s\n", entry.Path, entry.Description)
+		} else {
+			fmt.Fprintf(w, "- `%s`\n", entry.Path)
+		}
+	}
+
+	fmt.Fprintln(w)
+}
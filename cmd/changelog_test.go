@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun_Changelog(t *testing.T) {
+	dir := writeTestModule(t, "")
+
+	variables := `variable "name" {
+  type        = string
+  description = <<EOT
+    The name of the resource.
+
+    @since 1.0.0
+    @deprecated 2.0.0 Use id instead
+  EOT
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "variables.tf"), []byte(variables), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "## 2.0.0\n\n### Inputs deprecated\n\n- `name`: Use id instead\n\n" +
+		"## 1.0.0\n\n### Inputs added\n\n- `name`: The name of the resource.\n\n"
+
+	if code, stdout, stderr := runCLI("changelog", dir); code != exitOK || stdout != expected {
+		t.Fatalf("Expected the changelog, got %d: %s%s", code, stdout, stderr)
+	}
+
+	changelog := filepath.Join(dir, "CHANGELOG.md")
+	content := "# Changelog\n\n" + ChangelogMarkerStart + "\n" + ChangelogMarkerEnd + "\n\n## 0.1.0\n"
+	if err := os.WriteFile(changelog, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if code, _, stderr := runCLI("changelog", "--output", changelog, dir); code != exitOK {
+		t.Fatalf("Expected changelog to succeed, got %d: %s", code, stderr)
+	}
+
+	updated, _ := os.ReadFile(changelog)
+	if string(updated) != "# Changelog\n\n"+ChangelogMarkerStart+"\n\n"+expected+ChangelogMarkerEnd+"\n\n## 0.1.0\n" {
+		t.Errorf("Expected the changelog between the markers:\n%s", updated)
+	}
+
+	readme := filepath.Join(dir, "README.md")
+	if code, _, stderr := runCLI("changelog", "--output", readme, dir); code != exitError || !strings.Contains(stderr, "could not find start marker") {
+		t.Errorf("Expected a file without markers to be rejected, got %d: %s", code, stderr)
+	}
+}
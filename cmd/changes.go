@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/FriendsOfTerraform/tfdocs-extras"
+)
+
+// changeFormats lists the output formats of the diff command
+var changeFormats = []string{"text", "json"}
+
+func runDiff(args []string, stdout, stderr io.Writer) int {
+	var fromOpts, toOpts renderOptions
+	var format string
+
+	fs := newFlagSet("diff", "diff [flags] <old-module-path> <new-module-path>", stderr)
+	fs.StringVar(&format, "format", "text", "output format: text or json")
+
+	positional, err := parseFlags(fs, args)
+	if err == nil && len(positional) != 2 {
+		err = fmt.Errorf("%w: expected the paths of the old and new revisions of the module, got %d arguments", errUsage, len(positional))
+	}
+	if err == nil && !slices.Contains(changeFormats, format) {
+		err = fmt.Errorf("%w: invalid --format %q, expected one of %v", errUsage, format, changeFormats)
+	}
+	if err == nil {
+		err = fromOpts.load(fs, positional[0])
+	}
+	if err == nil {
+		err = toOpts.load(fs, positional[1])
+	}
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	from, err := loadModule(positional[0], &fromOpts)
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	to, err := loadModule(positional[1], &toOpts)
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	changes := tfdocextras.CompareModules(from, to)
+
+	if format == "json" {
+		if changes == nil {
+			changes = []tfdocextras.ModuleChange{}
+		}
+
+		content, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return exitCodeFor(err, stderr)
+		}
+
+		fmt.Fprintf(stdout, "%s\n", content)
+	} else {
+		printChanges(changes, stdout)
+	}
+
+	if tfdocextras.HasBreakingChanges(changes) {
+		return exitFailure
+	}
+
+	return exitOK
+}
+
+// printChanges prints every change followed by their count by severity
+func printChanges(changes []tfdocextras.ModuleChange, w io.Writer) {
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "No changes")
+		return
+	}
+
+	breaking := 0
+	for _, change := range changes {
+		fmt.Fprintln(w, change)
+
+		if change.Severity == tfdocextras.ChangeBreaking {
+			breaking++
+		}
+	}
+
+	fmt.Fprintf(w, "\n%d breaking and %d minor changes\n", breaking, len(changes)-breaking)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/FriendsOfTerraform/tfdocs-extras"
+	"github.com/go-test/deep"
+)
+
+func TestRun_Diff(t *testing.T) {
+	from := writeTestModule(t, "")
+	to := writeTestModule(t, "")
+
+	if code, stdout, stderr := runCLI("diff", from, to); code != exitOK || stdout != "No changes\n" {
+		t.Fatalf("Expected no changes, got %d: %s%s", code, stdout, stderr)
+	}
+
+	variables := testVariables + `
+variable "tags" {
+  type        = map(string)
+  description = "The tags of the resources"
+  default     = {}
+}
+`
+	if err := os.WriteFile(filepath.Join(to, "variables.tf"), []byte(variables), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runCLI("diff", from, to)
+	if code != exitOK || !strings.Contains(stdout, "minor: tags: an optional variable was added [field-added]") {
+		t.Fatalf("Expected a minor change, got %d: %s%s", code, stdout, stderr)
+	}
+
+	code, stdout, stderr = runCLI("diff", "--format", "json", to, from)
+	if code != exitFailure {
+		t.Fatalf("Expected the removed variable to exit with %d, got %d: %s", exitFailure, code, stderr)
+	}
+
+	var changes []tfdocextras.ModuleChange
+	if err := json.Unmarshal([]byte(stdout), &changes); err != nil {
+		t.Fatalf("Expected a JSON report, got %v:\n%s", err, stdout)
+	}
+
+	expected := []tfdocextras.ModuleChange{
+		{Severity: tfdocextras.ChangeBreaking, Code: tfdocextras.ChangeFieldRemoved, Path: "tags", Message: "the variable was removed"},
+	}
+
+	if diff := deep.Equal(changes, expected); diff != nil {
+		t.Errorf("Changes mismatch:\n%v", diff)
+	}
+
+	if code, _, _ := runCLI("diff", from); code != exitUsage {
+		t.Errorf("Expected a single module path to be rejected, got exit code %d", code)
+	}
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"sync"
+	"syscall"
+
+	"github.com/FriendsOfTerraform/tfdocs-extras"
+)
+
+// writeOutput writes content to path, or to stdout when path is "-"
+func writeOutput(path string, content []byte, backup bool, stdout io.Writer) error {
+	if path == "-" {
+		_, err := stdout.Write(content)
+		return err
+	}
+
+	return writeFileAtomic(path, content, backup)
+}
+
+func runGenerate(args []string, stdout, stderr io.Writer) int {
+	var opts renderOptions
+	var rec recursiveOptions
+	var check bool
+	var backup bool
+	var watch bool
+
+	fs := newFlagSet("generate", "generate [flags] [module-path]", stderr)
+	opts.register(fs)
+	rec.register(fs)
+	fs.StringVar(&opts.flags.Output, "output", "", "write the updated README to this file instead, or \"-\" for stdout")
+	fs.BoolVar(&check, "check", false, "do not write anything; same as the check command")
+	fs.BoolVar(&backup, "backup", false, "keep a copy of the file being replaced as <file>.bak")
+	fs.BoolVar(&watch, "watch", false, "regenerate the documentation whenever the module or template changes")
+
+	modulePath, err := parseCommand(fs, args)
+	if err == nil {
+		err = opts.load(fs, modulePath)
+	}
+	if err == nil {
+		err = rec.validate(fs)
+	}
+	if err == nil && watch && rec.enabled {
+		err = fmt.Errorf("%w: --watch cannot be used with --recursive", errUsage)
+	}
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	generate := func(modulePath string, opts *renderOptions, stdout, stderr io.Writer) int {
+		if check {
+			return checkReadme(modulePath, opts, stdout, stderr)
+		}
+
+		return generateReadme(modulePath, opts, backup, stdout, stderr)
+	}
+
+	if rec.enabled {
+		return runRecursive(modulePath, fs, &opts, &rec, generate, stdout, stderr)
+	}
+
+	if watch {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		regenerate := func() {
+			// Reload the configuration in case it changed as well
+			watchOpts := opts
+			if err := watchOpts.load(fs, modulePath); err != nil {
+				exitCodeFor(err, stderr)
+				return
+			}
+
+			generate(modulePath, &watchOpts, stdout, stderr)
+		}
+
+		if err := watchModule(ctx, modulePath, opts.Template, regenerate, stdout, stderr); err != nil {
+			return exitCodeFor(err, stderr)
+		}
+
+		return exitOK
+	}
+
+	return generate(modulePath, &opts, stdout, stderr)
+}
+
+// generateReadme renders the documentation into the module's README, or the
+// configured output file
+func generateReadme(modulePath string, opts *renderOptions, backup bool, stdout, stderr io.Writer) int {
+	_, updated, err := renderReadme(modulePath, opts, stderr)
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	destination := opts.Output
+	if destination == "" {
+		destination = opts.readme(modulePath)
+	}
+
+	if err := writeOutput(destination, []byte(updated), backup, stdout); err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	if destination != "-" {
+		fmt.Fprintf(stdout, "%s updated successfully\n", destination)
+	}
+
+	return exitOK
+}
+
+func runCheck(args []string, stdout, stderr io.Writer) int {
+	var opts renderOptions
+	var rec recursiveOptions
+
+	fs := newFlagSet("check", "check [flags] [module-path]", stderr)
+	opts.register(fs)
+	rec.register(fs)
+
+	modulePath, err := parseCommand(fs, args)
+	if err == nil {
+		err = opts.load(fs, modulePath)
+	}
+	if err == nil {
+		err = rec.validate(fs)
+	}
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	if rec.enabled {
+		return runRecursive(modulePath, fs, &opts, &rec, checkReadme, stdout, stderr)
+	}
+
+	return checkReadme(modulePath, &opts, stdout, stderr)
+}
+
+// checkReadme renders the documentation in memory and prints a unified diff of
+// the changes generate would make to the README, without writing anything
+func checkReadme(modulePath string, opts *renderOptions, stdout, stderr io.Writer) int {
+	current, updated, err := renderReadme(modulePath, opts, stderr)
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	readmePath := opts.readme(modulePath)
+
+	if diff := unifiedDiff(readmePath, readmePath+" (generated)", current, updated); diff != "" {
+		fmt.Fprint(stdout, diff)
+		fmt.Fprintf(stderr, "%s is out of date; run `tfdocs-extra generate` to update it\n", readmePath)
+
+		return exitFailure
+	}
+
+	fmt.Fprintf(stdout, "%s is up to date\n", readmePath)
+
+	return exitOK
+}
+
+func runLint(args []string, stdout, stderr io.Writer) int {
+	var opts renderOptions
+	var rec recursiveOptions
+	var format string
+
+	fs := newFlagSet("lint", "lint [flags] [module-path]", stderr)
+	fs.BoolVar(&opts.flags.Strict, "strict", false, "fail on warnings as well as errors")
+	fs.StringVar(&format, "format", "text", "output format: text, json, or sarif")
+	rec.register(fs)
+
+	modulePath, err := parseCommand(fs, args)
+	if err == nil {
+		err = opts.load(fs, modulePath)
+	}
+	if err == nil {
+		err = rec.validate(fs)
+	}
+	if err == nil && !slices.Contains(lintFormats, format) {
+		err = fmt.Errorf("%w: invalid --format %q, expected one of %v", errUsage, format, lintFormats)
+	}
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	if format == "text" {
+		if rec.enabled {
+			return runRecursive(modulePath, fs, &opts, &rec, lintModule, stdout, stderr)
+		}
+
+		return lintModule(modulePath, &opts, stdout, stderr)
+	}
+
+	// Structured formats report the diagnostics of every module in one document
+	var mu sync.Mutex
+	var diagnostics []tfdocextras.Diagnostic
+
+	collect := func(modulePath string, opts *renderOptions, stdout, stderr io.Writer) int {
+		manifest, err := loadManifest(modulePath, opts)
+		if err != nil {
+			return exitCodeFor(err, stderr)
+		}
+
+		mu.Lock()
+		diagnostics = append(diagnostics, manifest.Diagnostics...)
+		mu.Unlock()
+
+		return lintExitCode(manifest.Diagnostics, opts.Strict)
+	}
+
+	var code int
+	if rec.enabled {
+		// Keep stdout for the report
+		code = runRecursive(modulePath, fs, &opts, &rec, collect, stderr, stderr)
+	} else {
+		code = collect(modulePath, &opts, stdout, stderr)
+	}
+
+	if err := writeLintReport(format, diagnostics, stdout); err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	return code
+}
+
+// lintModule prints the diagnostics of a module and fails on errors, or on any
+// diagnostic in strict mode
+func lintModule(modulePath string, opts *renderOptions, stdout, stderr io.Writer) int {
+	manifest, err := loadManifest(modulePath, opts)
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	printDiagnostics(manifest, stdout)
+
+	if len(manifest.Diagnostics) == 0 {
+		fmt.Fprintln(stdout, "No problems found")
+	}
+
+	return lintExitCode(manifest.Diagnostics, opts.Strict)
+}
+
+// jsonFormats lists the documents the json command prints
+var jsonFormats = []string{"manifest", "schema"}
+
+func runJSON(args []string, stdout, stderr io.Writer) int {
+	var opts renderOptions
+	var output string
+	var format string
+
+	fs := newFlagSet("json", "json [flags] [module-path]", stderr)
+	opts.registerManifestFlags(fs)
+	fs.StringVar(&output, "output", "-", "write the document to this file, or \"-\" for stdout")
+	fs.StringVar(&format, "format", "manifest", "document to print: manifest, or schema for a JSON Schema of the inputs")
+
+	modulePath, err := parseCommand(fs, args)
+	if err == nil {
+		err = opts.load(fs, modulePath)
+	}
+	if err == nil && !slices.Contains(jsonFormats, format) {
+		err = fmt.Errorf("%w: invalid --format %q, expected one of %v", errUsage, format, jsonFormats)
+	}
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	var document any
+
+	if format == "schema" {
+		module, err := loadModule(modulePath, &opts)
+		if err != nil {
+			return exitCodeFor(err, stderr)
+		}
+
+		document = tfdocextras.GenerateJSONSchema(module.Inputs)
+	} else {
+		manifest, err := loadManifest(modulePath, &opts)
+		if err != nil {
+			return exitCodeFor(err, stderr)
+		}
+
+		document = manifest
+	}
+
+	content, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	if err := writeOutput(output, append(content, '\n'), false, stdout); err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	return exitOK
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/FriendsOfTerraform/tfdocs-extras"
+	"github.com/spf13/viper"
+)
+
+const configFileName = ".tfdocs-extras.yml"
+
+// sortOrders lists the supported values of the `sort` setting; "source" keeps
+// the order in which inputs and outputs are declared
+var sortOrders = []string{"name", "required", "type", "source"}
+
+// lintConfig holds the settings of the lint rules
+type lintConfig struct {
+	// Rules overrides the severity of rules, by code; "off" disables a rule
+	Rules map[string]tfdocextras.DiagnosticSeverity `mapstructure:"rules"`
+}
+
+type markersConfig struct {
+	Start string `mapstructure:"start"`
+	End   string `mapstructure:"end"`
+}
+
+// projectConfig holds the settings of a `.tfdocs-extras.yml` file. Every
+// setting can also be set with the command line flag of the same name.
+type projectConfig struct {
+	// Readme and Output are relative to the module
+	Readme string `mapstructure:"readme"`
+	Output string `mapstructure:"output"`
+
+	Markers markersConfig `mapstructure:"markers"`
+
+	// Template is relative to the configuration file declaring it
+	Template string `mapstructure:"template"`
+
+	Sort             string   `mapstructure:"sort"`
+	HiddenDirectives []string `mapstructure:"hidden-directives"`
+	Strict           bool     `mapstructure:"strict"`
+	CurrentVersion   string   `mapstructure:"current-version"`
+
+	Lint lintConfig `mapstructure:"lint"`
+
+	// MinCoverage is the percentage of documented fields below which the
+	// coverage command fails; CoverageBadge is relative to the module
+	MinCoverage   float64 `mapstructure:"min-coverage"`
+	CoverageBadge string  `mapstructure:"coverage-badge"`
+}
+
+func defaultConfig() projectConfig {
+	return projectConfig{
+		Markers: markersConfig{
+			Start: ExtrasMarkerStart,
+			End:   ExtrasMarkerEnd,
+		},
+		Sort: "name",
+	}
+}
+
+func (c *projectConfig) validate() error {
+	if !slices.Contains(sortOrders, c.Sort) {
+		return fmt.Errorf("invalid sort %q, expected one of %v", c.Sort, sortOrders)
+	}
+
+	if c.Markers.Start == "" || c.Markers.End == "" {
+		return errors.New("markers must not be empty")
+	}
+
+	for code, severity := range c.Lint.Rules {
+		if !slices.ContainsFunc(tfdocextras.Rules(), func(rule tfdocextras.Rule) bool { return rule.Code == code }) {
+			return fmt.Errorf("unknown lint rule %q", code)
+		}
+
+		switch severity {
+		case tfdocextras.SeverityError, tfdocextras.SeverityWarning, tfdocextras.SeverityOff:
+		default:
+			return fmt.Errorf("invalid severity %q for lint rule %q, expected error, warning, or off", severity, code)
+		}
+	}
+
+	if c.MinCoverage < 0 || c.MinCoverage > 100 {
+		return fmt.Errorf("invalid min-coverage %v, expected a percentage between 0 and 100", c.MinCoverage)
+	}
+
+	if c.CurrentVersion != "" {
+		if _, err := tfdocextras.ParseVersion(c.CurrentVersion); err != nil {
+			return fmt.Errorf("invalid current-version: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// findConfigFiles returns the configuration files found in modulePath and its
+// parent directories up to the root of the repository (the directory containing
+// `.git`), or of the filesystem outside of a repository, the farthest first
+func findConfigFiles(modulePath string) ([]string, error) {
+	dir, err := filepath.Abs(modulePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for {
+		path := filepath.Join(dir, configFileName)
+
+		if _, err := os.Stat(path); err == nil {
+			paths = append(paths, path)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			break
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+
+		dir = parent
+	}
+
+	slices.Reverse(paths)
+
+	return paths, nil
+}
+
+// loadConfig merges the default settings with every configuration file found
+// from the repository root down to modulePath, so the configuration nearest to
+// the module takes precedence over those of its parents
+func loadConfig(modulePath string) (projectConfig, error) {
+	config := defaultConfig()
+
+	paths, err := findConfigFiles(modulePath)
+	if err != nil {
+		return config, err
+	}
+
+	for _, path := range paths {
+		if err := decodeConfigFile(path, &config); err != nil {
+			return config, err
+		}
+	}
+
+	if config.Readme != "" && !filepath.IsAbs(config.Readme) {
+		config.Readme = filepath.Join(modulePath, config.Readme)
+	}
+
+	if config.Output != "" && config.Output != "-" && !filepath.IsAbs(config.Output) {
+		config.Output = filepath.Join(modulePath, config.Output)
+	}
+
+	if config.CoverageBadge != "" && !filepath.IsAbs(config.CoverageBadge) {
+		config.CoverageBadge = filepath.Join(modulePath, config.CoverageBadge)
+	}
+
+	return config, nil
+}
+
+// decodeConfigFile overwrites the settings of config with those set in the file
+// at path, rejecting unknown keys. Like terraform-docs with `.terraform-docs.yml`,
+// the file is read with viper.
+func decodeConfigFile(path string, config *projectConfig) error {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+
+	if err := v.ReadInConfig(); err != nil {
+		var pathErr *os.PathError
+		if errors.As(err, &pathErr) {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		return fmt.Errorf("invalid configuration %s: %w", path, err)
+	}
+
+	previousTemplate := config.Template
+
+	if err := v.UnmarshalExact(config); err != nil {
+		return fmt.Errorf("invalid configuration %s: %w", path, err)
+	}
+
+	if config.Template != previousTemplate && config.Template != "" && !filepath.IsAbs(config.Template) {
+		config.Template = filepath.Join(filepath.Dir(path), config.Template)
+	}
+
+	return nil
+}
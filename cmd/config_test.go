@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/FriendsOfTerraform/tfdocs-extras"
+	"github.com/go-test/deep"
+)
+
+func writeConfig(t *testing.T, dir, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, configFileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadConfig_Precedence(t *testing.T) {
+	root := t.TempDir()
+	module := filepath.Join(root, "modules", "efs")
+
+	writeConfig(t, root, "sort: source\nstrict: true\ntemplate: docs.tmpl\nmarkers:\n  start: <!-- BEGIN -->\n  end: <!-- END -->\n")
+	writeConfig(t, module, "sort: required\nmarkers:\n  end: <!-- FINISH -->\nreadme: docs/README.md\n")
+
+	var opts renderOptions
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	opts.register(fs)
+
+	if err := fs.Parse([]string{"--sort", "type"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := opts.load(fs, module); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	expected := projectConfig{
+		Readme:   filepath.Join(module, "docs", "README.md"),
+		Markers:  markersConfig{Start: "<!-- BEGIN -->", End: "<!-- FINISH -->"},
+		Template: filepath.Join(root, "docs.tmpl"),
+		Sort:     "type",
+		Strict:   true,
+	}
+
+	if diff := deep.Equal(opts.projectConfig, expected); diff != nil {
+		t.Errorf("Configuration mismatch:\n%v", diff)
+	}
+}
+
+func TestLoadConfig_StopsAtRepositoryRoot(t *testing.T) {
+	outside := t.TempDir()
+	repository := filepath.Join(outside, "repository")
+	module := filepath.Join(repository, "modules", "efs")
+
+	writeConfig(t, outside, "sort: source\n")
+	writeConfig(t, repository, "strict: true\n")
+
+	if err := os.MkdirAll(filepath.Join(repository, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := loadConfig(module)
+	if err != nil {
+		t.Fatalf("loadConfig failed: %v", err)
+	}
+
+	// The configuration above the repository is ignored
+	if config.Sort != "name" || !config.Strict {
+		t.Errorf("Expected only the repository configuration, got %+v", config)
+	}
+}
+
+func TestLoadConfig_InvalidConfig(t *testing.T) {
+	tests := map[string]string{
+		"unknown key":   "markers:\n  begin: <!-- BEGIN -->\n",
+		"invalid sort":  "sort: alphabetical\n",
+		"invalid value": "strict: sometimes\n",
+		"invalid min":   "min-coverage: 120\n",
+	}
+
+	for name, content := range tests {
+		dir := t.TempDir()
+		writeConfig(t, dir, content)
+
+		var opts renderOptions
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		opts.register(fs)
+
+		if err := opts.load(fs, dir); err == nil || !strings.Contains(err.Error(), "configuration") {
+			t.Errorf("%s: expected a configuration error, got %v", name, err)
+		}
+	}
+}
+
+func TestRun_GenerateWithConfig(t *testing.T) {
+	dir := writeTestModule(t, "<!-- DOCS -->\n<!-- /DOCS -->\n")
+	writeConfig(t, dir, "markers:\n  start: <!-- DOCS -->\n  end: <!-- /DOCS -->\nhidden-directives: [since]\n")
+
+	if code, _, stderr := runCLI("generate", dir); code != exitOK {
+		t.Fatalf("Expected generate to succeed, got %d: %s", code, stderr)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(dir, "README.md"))
+	if !strings.Contains(string(content), "The name of the resource") {
+		t.Errorf("Expected the configured markers to be used:\n%s", content)
+	}
+
+	// Flags take precedence over the configuration
+	if code, _, _ := runCLI("check", "--start-marker", ExtrasMarkerStart, "--end-marker", ExtrasMarkerEnd, dir); code != exitError {
+		t.Errorf("Expected the marker flags to override the configuration, got exit code %d", code)
+	}
+}
+
+func TestRun_LintWithRuleSeverities(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "module")
+
+	writeConfig(t, root, "lint:\n  rules:\n    undocumented: error\n    unknown-directive: error\n")
+	writeConfig(t, dir, "lint:\n  rules:\n    unknown-directive: off\n")
+
+	variables := "variable \"name\" {\n  type = string\n  description = \"The name\\n\\n@todo\"\n}\n\nvariable \"id\" {\n  type = string\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "variables.tf"), []byte(variables), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runCLI("lint", "--format", "json", dir)
+	if code != exitFailure {
+		t.Fatalf("Expected lint to fail, got %d: %s", code, stderr)
+	}
+
+	var diagnostics []tfdocextras.Diagnostic
+	if err := json.Unmarshal([]byte(stdout), &diagnostics); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, stdout)
+	}
+
+	// The parent's severity applies, the module disables the unknown directive
+	if len(diagnostics) != 1 || diagnostics[0].Code != tfdocextras.DiagUndocumented || diagnostics[0].Severity != tfdocextras.SeverityError {
+		t.Errorf("Unexpected diagnostics: %+v", diagnostics)
+	}
+}
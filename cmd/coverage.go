@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/FriendsOfTerraform/tfdocs-extras"
+)
+
+//go:embed templates/badge.svg
+var badgeTmplContent string
+
+var badgeTmpl = template.Must(template.New("badge.svg").Parse(badgeTmplContent))
+
+const badgeLabel = "docs coverage"
+
+// badgeCharWidth approximates the width of a character of the badge font, which
+// is enough for the short texts of a badge
+const badgeCharWidth = 7
+
+// badge is the data of the badge.svg template
+type badge struct {
+	Label, Value, Color    string
+	Width                  int
+	LabelWidth, ValueWidth int
+	LabelX, ValueX         float64
+}
+
+func newBadge(label, value, color string) badge {
+	b := badge{
+		Label:      label,
+		Value:      value,
+		Color:      color,
+		LabelWidth: len(label)*badgeCharWidth + 10,
+		ValueWidth: len(value)*badgeCharWidth + 10,
+	}
+
+	b.Width = b.LabelWidth + b.ValueWidth
+	b.LabelX = float64(b.LabelWidth) / 2
+	b.ValueX = float64(b.LabelWidth) + float64(b.ValueWidth)/2
+
+	return b
+}
+
+// coverageColor picks the color of the badge the way shields.io colors
+// coverage badges
+func coverageColor(percent float64) string {
+	switch {
+	case percent >= 90:
+		return "#4c1"
+	case percent >= 75:
+		return "#97ca00"
+	case percent >= 60:
+		return "#dfb317"
+	case percent >= 40:
+		return "#fe7d37"
+	default:
+		return "#e05d44"
+	}
+}
+
+// formatPercent rounds a percentage down to one decimal, so that a module just
+// below a threshold is never displayed as reaching it
+func formatPercent(percent float64) string {
+	return strconv.FormatFloat(math.Floor(percent*10)/10, 'f', -1, 64) + "%"
+}
+
+func runCoverage(args []string, stdout, stderr io.Writer) int {
+	var opts renderOptions
+	var rec recursiveOptions
+
+	fs := newFlagSet("coverage", "coverage [flags] [module-path]", stderr)
+	fs.Float64Var(&opts.flags.MinCoverage, "min-coverage", 0, "fail when less than this percentage of the fields are documented")
+	fs.StringVar(&opts.flags.CoverageBadge, "coverage-badge", "", "write an SVG badge of the coverage to this file")
+	rec.register(fs)
+
+	modulePath, err := parseCommand(fs, args)
+	if err == nil {
+		err = opts.load(fs, modulePath)
+	}
+	if err == nil {
+		err = rec.validate(fs)
+	}
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	if rec.enabled {
+		return runRecursive(modulePath, fs, &opts, &rec, coverModule, stdout, stderr)
+	}
+
+	return coverModule(modulePath, &opts, stdout, stderr)
+}
+
+// coverModule prints the documentation coverage of a module, writes its badge
+// if configured, and fails below the minimum coverage
+func coverModule(modulePath string, opts *renderOptions, stdout, stderr io.Writer) int {
+	module, err := loadModule(modulePath, opts)
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	coverage := tfdocextras.ComputeCoverage(module.Inputs)
+
+	if err := writeCoverageReport(coverage, stdout); err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	if opts.CoverageBadge != "" {
+		var content bytes.Buffer
+		if err := badgeTmpl.Execute(&content, newBadge(badgeLabel, formatPercent(coverage.Percent), coverageColor(coverage.Percent))); err != nil {
+			return exitCodeFor(err, stderr)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(opts.CoverageBadge), 0755); err != nil {
+			return exitCodeFor(err, stderr)
+		}
+
+		if err := writeFileAtomic(opts.CoverageBadge, content.Bytes(), false); err != nil {
+			return exitCodeFor(err, stderr)
+		}
+	}
+
+	if coverage.Percent < opts.MinCoverage {
+		fmt.Fprintf(stderr, "%s: coverage %s is below the minimum of %s\n", modulePath, formatPercent(coverage.Percent), formatPercent(opts.MinCoverage))
+		return exitFailure
+	}
+
+	return exitOK
+}
+
+// writeCoverageReport prints the coverage of every variable, the undocumented
+// fields, and the coverage of the module
+func writeCoverageReport(coverage tfdocextras.ModuleCoverage, w io.Writer) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(table, "VARIABLE\tCOVERAGE\tDOCUMENTED")
+	for _, variable := range coverage.Variables {
+		fmt.Fprintf(table, "%s\t%s\t%d/%d\n", variable.Name, formatPercent(variable.Percent), variable.Documented, variable.Fields)
+	}
+
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	if len(coverage.Undocumented) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Undocumented:")
+
+		for _, path := range coverage.Undocumented {
+			fmt.Fprintf(w, "  %s\n", path)
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "\nModule coverage: %s (%d/%d fields documented)\n", formatPercent(coverage.Percent), coverage.Documented, coverage.Fields)
+
+	return err
+}
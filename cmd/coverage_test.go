@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun_Coverage(t *testing.T) {
+	dir := writeTestModule(t, "")
+
+	variables := testVariables + "\nvariable \"tags\" {\n  type = object({\n    /// The owner\n    owner = string\n    team  = string\n  })\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "variables.tf"), []byte(variables), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runCLI("coverage", dir)
+	if code != exitOK {
+		t.Fatalf("Expected coverage to succeed, got %d: %s", code, stderr)
+	}
+
+	for _, expected := range []string{"tags      33.3%     1/3", "  tags.team\n", "Module coverage: 50% (2/4 fields documented)"} {
+		if !strings.Contains(stdout, expected) {
+			t.Errorf("Expected the report to contain %q:\n%s", expected, stdout)
+		}
+	}
+
+	// The configured minimum and badge apply, the badge is relative to the module
+	writeConfig(t, dir, "min-coverage: 75\ncoverage-badge: docs/coverage.svg\n")
+
+	if code, _, stderr := runCLI("coverage", dir); code != exitFailure || !strings.Contains(stderr, "below the minimum of 75%") {
+		t.Errorf("Expected coverage to fail below the minimum, got %d: %s", code, stderr)
+	}
+
+	badge, err := os.ReadFile(filepath.Join(dir, "docs", "coverage.svg"))
+	if err != nil || !strings.Contains(string(badge), `aria-label="docs coverage: 50%"`) {
+		t.Errorf("Expected a coverage badge, got %v:\n%s", err, badge)
+	}
+
+	if code, _, stderr := runCLI("coverage", "--min-coverage", "50", dir); code != exitOK {
+		t.Errorf("Expected the flag to override the configured minimum, got %d: %s", code, stderr)
+	}
+}
+
+func TestFormatPercent(t *testing.T) {
+	tests := map[float64]string{
+		100:         "100%",
+		50:          "50%",
+		100.0 / 3:   "33.3%",
+		79.99999999: "79.9%",
+	}
+
+	for percent, expected := range tests {
+		if actual := formatPercent(percent); actual != expected {
+			t.Errorf("formatPercent(%v) = %q, expected %q", percent, actual, expected)
+		}
+	}
+}
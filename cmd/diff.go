@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const diffContextLines = 3
+
+type diffKind byte
+
+const (
+	diffEqual  diffKind = ' '
+	diffDelete diffKind = '-'
+	diffInsert diffKind = '+'
+)
+
+type diffOp struct {
+	kind diffKind
+	line string
+}
+
+// diffLines computes the shortest edit script turning a into b using Myers'
+// O(ND) algorithm
+func diffLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+	var trace [][]int
+
+	for d := 0; d <= n+m; d++ {
+		// Backtracking round d only reads the diagonals -d-1 to d+1 of the
+		// previous round, so the trace grows with the number of edits squared
+		// rather than with the size of the texts
+		trace = append(trace, slices.Clone(v[offset-d-1:offset+d+2]))
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				return backtrackDiff(trace, a, b)
+			}
+		}
+	}
+
+	return nil
+}
+
+// backtrackDiff follows the trace of diffLines back from the end of both
+// texts; trace[d] holds the diagonals -d-1 to d+1 before round d
+func backtrackDiff(trace [][]int, a, b []string) []diffOp {
+	var ops []diffOp
+	x, y := len(a), len(b)
+
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		offset := d + 1
+		k := x - y
+
+		prevK := k - 1
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		}
+
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			ops = append(ops, diffOp{kind: diffEqual, line: a[x-1]})
+			x--
+			y--
+		}
+
+		if d > 0 {
+			if x == prevX {
+				ops = append(ops, diffOp{kind: diffInsert, line: b[y-1]})
+			} else {
+				ops = append(ops, diffOp{kind: diffDelete, line: a[x-1]})
+			}
+		}
+
+		x, y = prevX, prevY
+	}
+
+	slices.Reverse(ops)
+
+	return ops
+}
+
+// splitLines splits text into lines that keep their line feed, so that a last
+// line missing one differs from the same line followed by one
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// unifiedDiff renders the differences between two texts in the unified diff
+// format, marking a last line without a line feed the way diff does; it returns
+// an empty string when both texts are identical
+func unifiedDiff(fromName, toName, from, to string) string {
+	ops := diffLines(splitLines(from), splitLines(to))
+
+	var out strings.Builder
+	for start := 0; start < len(ops); {
+		first := start
+		for first < len(ops) && ops[first].kind == diffEqual {
+			first++
+		}
+
+		if first == len(ops) {
+			break
+		}
+
+		// Changes separated by fewer than two contexts worth of lines share a hunk
+		last := first
+		for i := first + 1; i < len(ops); i++ {
+			if ops[i].kind == diffEqual {
+				continue
+			}
+
+			if i-last-1 > 2*diffContextLines {
+				break
+			}
+
+			last = i
+		}
+
+		hunkStart := max(first-diffContextLines, start)
+		hunkEnd := min(last+1+diffContextLines, len(ops))
+
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+		}
+
+		writeHunk(&out, ops, hunkStart, hunkEnd)
+		start = hunkEnd
+	}
+
+	return out.String()
+}
+
+func writeHunk(out *strings.Builder, ops []diffOp, start, end int) {
+	// Line numbers of the hunk's first line in each file
+	fromLine, toLine := 1, 1
+	for _, op := range ops[:start] {
+		if op.kind != diffInsert {
+			fromLine++
+		}
+		if op.kind != diffDelete {
+			toLine++
+		}
+	}
+
+	fromCount, toCount := 0, 0
+	for _, op := range ops[start:end] {
+		if op.kind != diffInsert {
+			fromCount++
+		}
+		if op.kind != diffDelete {
+			toCount++
+		}
+	}
+
+	fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(fromLine, fromCount), hunkRange(toLine, toCount))
+
+	for _, op := range ops[start:end] {
+		if line, ok := strings.CutSuffix(op.line, "\n"); ok {
+			fmt.Fprintf(out, "%c%s\n", op.kind, line)
+		} else {
+			fmt.Fprintf(out, "%c%s\n\\ No newline at end of file\n", op.kind, op.line)
+		}
+	}
+}
+
+func hunkRange(line, count int) string {
+	if count == 0 {
+		// An empty range refers to the line before the hunk
+		return fmt.Sprintf("%d,0", line-1)
+	}
+
+	if count == 1 {
+		return fmt.Sprintf("%d", line)
+	}
+
+	return fmt.Sprintf("%d,%d", line, count)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestUnifiedDiff_Identical(t *testing.T) {
+	if diff := unifiedDiff("a", "b", "one\ntwo\n", "one\ntwo\n"); diff != "" {
+		t.Errorf("Expected no diff, got:\n%s", diff)
+	}
+}
+
+func TestUnifiedDiff_SeparateHunks(t *testing.T) {
+	from := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	to := "1\ntwo\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n"
+
+	expected := `--- a/README.md
++++ b/README.md
+@@ -1,5 +1,5 @@
+ 1
+-2
++two
+ 3
+ 4
+ 5
+@@ -10,3 +10,4 @@
+ 10
+ 11
+ 12
++13
+`
+
+	if diff := unifiedDiff("a/README.md", "b/README.md", from, to); diff != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, diff)
+	}
+}
+
+func TestUnifiedDiff_MergedHunk(t *testing.T) {
+	from := "a\nb\nc\nd\ne\n"
+	to := "a\nB\nc\nd\nE\n"
+
+	expected := `--- old
++++ new
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+-e
++E
+`
+
+	if diff := unifiedDiff("old", "new", from, to); diff != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, diff)
+	}
+}
+
+func TestUnifiedDiff_NoNewlineAtEndOfFile(t *testing.T) {
+	expected := `--- old
++++ new
+@@ -1,2 +1,2 @@
+ one
+-two
+\ No newline at end of file
++two
+`
+
+	if diff := unifiedDiff("old", "new", "one\ntwo", "one\ntwo\n"); diff != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, diff)
+	}
+}
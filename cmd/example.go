@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/FriendsOfTerraform/tfdocs-extras"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// exampleVariants and exampleFormats list the examples the example command
+// generates
+var (
+	exampleVariants = []string{string(tfdocextras.ExampleMinimal), string(tfdocextras.ExampleFull)}
+	exampleFormats  = []string{"tfvars", "module"}
+)
+
+func runExample(args []string, stdout, stderr io.Writer) int {
+	var opts renderOptions
+	var output, variant, format, name, source string
+
+	fs := newFlagSet("example", "example [flags] [module-path]", stderr)
+	fs.StringVar(&output, "output", "-", "write the example to this file (e.g. example.tfvars), or \"-\" for stdout")
+	fs.StringVar(&variant, "variant", string(tfdocextras.ExampleFull), "minimal for the required inputs only, or full to show the optional ones commented out")
+	fs.StringVar(&format, "format", "tfvars", "tfvars for a variables file, or module for a module block")
+	fs.StringVar(&name, "name", "", "name of the module block (default: the module's folder name)")
+	fs.StringVar(&source, "source", "", "source of the module block (default: the module path)")
+
+	modulePath, err := parseCommand(fs, args)
+	if err == nil {
+		err = opts.load(fs, modulePath)
+	}
+	if err == nil && !slices.Contains(exampleVariants, variant) {
+		err = fmt.Errorf("%w: invalid --variant %q, expected one of %v", errUsage, variant, exampleVariants)
+	}
+	if err == nil && !slices.Contains(exampleFormats, format) {
+		err = fmt.Errorf("%w: invalid --format %q, expected one of %v", errUsage, format, exampleFormats)
+	}
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	module, err := loadModule(modulePath, &opts)
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	var content []byte
+
+	if format == "module" {
+		if name == "" {
+			name = moduleBlockName(modulePath)
+		}
+		if source == "" {
+			source = localSource(modulePath)
+		}
+
+		content = tfdocextras.GenerateExampleModule(module.Inputs, name, source, tfdocextras.ExampleVariant(variant))
+	} else {
+		content = tfdocextras.GenerateExampleVariables(module.Inputs, tfdocextras.ExampleVariant(variant))
+	}
+
+	if err := writeOutput(output, content, false, stdout); err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	if output != "-" {
+		fmt.Fprintf(stdout, "%s updated successfully\n", output)
+	}
+
+	return exitOK
+}
+
+// moduleBlockName returns the name of the folder of a module when it is a valid
+// block label, and "this" otherwise
+func moduleBlockName(modulePath string) string {
+	if abs, err := filepath.Abs(modulePath); err == nil && hclsyntax.ValidIdentifier(filepath.Base(abs)) {
+		return filepath.Base(abs)
+	}
+
+	return "this"
+}
+
+// localSource returns modulePath as a Terraform local module source, which must
+// start with "./" or "../"
+func localSource(modulePath string) string {
+	source := filepath.ToSlash(filepath.Clean(modulePath))
+
+	switch {
+	case source == ".":
+		return "./"
+	case filepath.IsAbs(modulePath), source == "..", strings.HasPrefix(source, "../"):
+		return source
+	}
+
+	return "./" + source
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun_Example(t *testing.T) {
+	dir := writeTestModule(t, "")
+
+	code, stdout, stderr := runCLI("example", dir)
+	if code != exitOK || stdout != "# The name of the resource\nname = \"\"\n" {
+		t.Fatalf("Expected the example variables, got %d: %s%s", code, stdout, stderr)
+	}
+
+	code, stdout, stderr = runCLI("example", "--format", "module", "--name", "app", "--source", "git::https://example.com/app.git", dir)
+	if code != exitOK || !strings.HasPrefix(stdout, "module \"app\" {\n  source = \"git::https://example.com/app.git\"\n\n  # The name of the resource\n  name = \"\"\n}") {
+		t.Fatalf("Expected the example module block, got %d: %s%s", code, stdout, stderr)
+	}
+
+	output := filepath.Join(dir, "example.tfvars")
+	if code, _, stderr := runCLI("example", "--variant", "minimal", "--output", output, dir); code != exitOK {
+		t.Fatalf("Expected example to succeed, got %d: %s", code, stderr)
+	}
+
+	if content, _ := os.ReadFile(output); !strings.Contains(string(content), "name = \"\"") {
+		t.Errorf("Expected the example to be written:\n%s", content)
+	}
+
+	if code, _, _ := runCLI("example", "--variant", "everything", dir); code != exitUsage {
+		t.Errorf("Expected an unknown variant to be rejected, got exit code %d", code)
+	}
+}
+
+func TestLocalSource(t *testing.T) {
+	tests := map[string]string{
+		".":                "./",
+		"modules/efs":      "./modules/efs",
+		"./modules/efs/":   "./modules/efs",
+		"../efs":           "../efs",
+		"/srv/modules/efs": "/srv/modules/efs",
+	}
+
+	for modulePath, expected := range tests {
+		if actual := localSource(modulePath); actual != expected {
+			t.Errorf("localSource(%q) = %q, expected %q", modulePath, actual, expected)
+		}
+	}
+}
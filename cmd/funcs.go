@@ -0,0 +1,88 @@
+package main
+
+import (
+	"html"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// markdownSpecialChars are the characters escapeMarkdown prefixes with a backslash
+const markdownSpecialChars = "\\`*_{}[]()<>#+-.!|~"
+
+// templateFuncs returns the functions available to the documentation templates
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"indent":         indent,
+		"escapeMarkdown": escapeMarkdown,
+		"escapeHTML":     html.EscapeString,
+		"anchor":         anchor,
+		"join":           join,
+		"default":        defaultValue,
+		"upper":          strings.ToUpper,
+		"lower":          strings.ToLower,
+	}
+}
+
+// indent prefixes every non-empty line of str with the given number of spaces
+func indent(spaces int, str string) string {
+	padding := strings.Repeat(" ", spaces)
+	lines := strings.Split(str, "\n")
+
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = padding + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// escapeMarkdown escapes the characters markdown would otherwise interpret, so
+// str is rendered literally
+func escapeMarkdown(str string) string {
+	var escaped strings.Builder
+
+	for _, r := range str {
+		if strings.ContainsRune(markdownSpecialChars, r) {
+			escaped.WriteByte('\\')
+		}
+
+		escaped.WriteRune(r)
+	}
+
+	return escaped.String()
+}
+
+// anchor returns the fragment GitHub generates for a heading with the given
+// text: lowercased, with spaces turned into hyphens and punctuation removed
+func anchor(str string) string {
+	var slug strings.Builder
+
+	for _, r := range strings.ToLower(strings.TrimSpace(str)) {
+		switch {
+		case r == ' ':
+			slug.WriteByte('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			slug.WriteRune(r)
+		}
+	}
+
+	return slug.String()
+}
+
+// join concatenates the elements of a list with sep, so it can be used at the
+// end of a pipeline: {{.Enumerations | join ", "}}
+func join(sep string, elems []string) string {
+	return strings.Join(elems, sep)
+}
+
+// defaultValue returns value unless it is empty, in which case fallback is
+// returned: {{.DefaultValue | default "n/a"}}
+func defaultValue(fallback string, value string) string {
+	if value == "" {
+		return fallback
+	}
+
+	return value
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	tests := map[string]string{
+		`{{indent 2 "a\n\nb"}}`:                        "  a\n\n  b",
+		`{{escapeMarkdown "*bold* [x](y) a_b"}}`:       `\*bold\* \[x\]\(y\) a\_b`,
+		`{{escapeHTML "<td>&</td>"}}`:                  "&lt;td&gt;&amp;&lt;/td&gt;",
+		`{{anchor "What's New in 1.2.0"}}`:             "whats-new-in-120",
+		`{{anchor "Objects_Config"}}`:                  "objects_config",
+		`{{.List | join ", "}}`:                        "a, b",
+		`{{"" | default "n/a"}}|{{"x" | default "y"}}`: "n/a|x",
+		`{{upper "abc"}}{{lower "DEF"}}`:               "ABCdef",
+	}
+
+	data := map[string][]string{"List": {"a", "b"}}
+
+	for text, expected := range tests {
+		tmpl, err := template.New("test").Funcs(templateFuncs()).Parse(text)
+		if err != nil {
+			t.Fatalf("%s: %v", text, err)
+		}
+
+		var output bytes.Buffer
+		if err := tmpl.Execute(&output, data); err != nil {
+			t.Fatalf("%s: %v", text, err)
+		}
+
+		if output.String() != expected {
+			t.Errorf("%s: expected %q, got %q", text, expected, output.String())
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// inputsRegion is the region init places under an existing heading, whose
+// template block renders the inputs without a heading of their own
+const inputsRegion = "inputs"
+
+// Markers of the region generated by terraform-docs
+const (
+	terraformDocsMarkerStart = "<!-- BEGIN_TF_DOCS -->"
+	terraformDocsMarkerEnd   = "<!-- END_TF_DOCS -->"
+)
+
+var headingRe = regexp.MustCompile(`^#{1,6}\s+(.*?)\s*#*$`)
+
+// initOptions controls where initReadme places the markers
+type initOptions struct {
+	// afterHeading is the text of the heading the markers of the inputs region
+	// follow; when empty or not found, the markers of the unnamed region are
+	// appended to the README
+	afterHeading string
+
+	// replaceInputs replaces the inputs table generated by terraform-docs, if
+	// any, with the markers
+	replaceInputs bool
+}
+
+func runInit(args []string, stdout, stderr io.Writer) int {
+	var opts renderOptions
+	var placement initOptions
+
+	fs := newFlagSet("init", "init [flags] [module-path]", stderr)
+	fs.StringVar(&opts.flags.Readme, "readme", "", "path to the README to create or update (default \"<module-path>/README.md\")")
+	fs.StringVar(&opts.flags.Markers.Start, "start-marker", ExtrasMarkerStart, "line marking the start of the generated documentation")
+	fs.StringVar(&opts.flags.Markers.End, "end-marker", ExtrasMarkerEnd, "line marking the end of the generated documentation")
+	fs.StringVar(&placement.afterHeading, "after-heading", "Inputs", "insert the markers after the heading with this text, or at the end of the README when empty or not found")
+	fs.BoolVar(&placement.replaceInputs, "replace-inputs", false, "replace the inputs table generated by terraform-docs with the markers")
+
+	modulePath, err := parseCommand(fs, args)
+	if err == nil {
+		err = opts.load(fs, modulePath)
+	}
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	readmePath := opts.readme(modulePath)
+
+	content, err := os.ReadFile(readmePath)
+	if errors.Is(err, os.ErrNotExist) {
+		title, _ := filepath.Abs(modulePath)
+		content = []byte("# " + filepath.Base(title) + "\n")
+	} else if err != nil {
+		return exitCodeFor(err, stderr)
+	} else if len(findRegionNames(strings.Split(string(content), "\n"), opts.Markers.Start)) > 0 {
+		fmt.Fprintf(stdout, "%s already has markers\n", readmePath)
+		return exitOK
+	}
+
+	updated, location := initReadme(string(content), opts.Markers, placement)
+
+	if err := writeFileAtomic(readmePath, []byte(updated), false); err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	fmt.Fprintf(stdout, "Added markers to %s %s\n", readmePath, location)
+	fmt.Fprintf(stdout, "Run `tfdocs-extra generate %s` to render the documentation\n", modulePath)
+
+	return exitOK
+}
+
+// initReadme inserts the start and end markers in content and describes where
+// they were placed. Markers placed under an existing heading delimit the inputs
+// region, so that the heading is not rendered twice.
+func initReadme(content string, markers markersConfig, placement initOptions) (string, string) {
+	eol := "\n"
+	if strings.Contains(content, "\r\n") {
+		eol = "\r\n"
+	}
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	region := []string{markers.Start, markers.End}
+	inputs := []string{namedMarker(markers.Start, inputsRegion), namedMarker(markers.End, inputsRegion)}
+
+	var location string
+
+	if start, end, found := findInputsTable(lines); placement.replaceInputs && found {
+		if docsEnd, inside := terraformDocsRegion(lines, start); inside {
+			// terraform-docs would overwrite markers placed within its region
+			lines = insertLines(lines, docsEnd+1, region)
+			location = fmt.Sprintf("after the terraform-docs region ending on line %d (hide its inputs section to avoid documenting the inputs twice)", docsEnd+1)
+		} else {
+			lines = slices.Replace(lines, start, end, inputs...)
+			location = fmt.Sprintf("in place of the inputs table on line %d", start+1)
+		}
+	} else if idx := findHeading(lines, placement.afterHeading); idx >= 0 {
+		lines = insertLines(lines, idx+1, inputs)
+		location = fmt.Sprintf("after the %q heading on line %d", strings.TrimSpace(lines[idx]), idx+1)
+	} else {
+		// Drop trailing blank lines, the region is followed by a final newline
+		for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+			lines = lines[:len(lines)-1]
+		}
+
+		lines = append(insertLines(lines, len(lines), region), "")
+		location = "at the end of the file"
+	}
+
+	return strings.Join(lines, eol), location
+}
+
+// insertLines inserts region at idx, separated from the surrounding lines by a
+// blank line
+func insertLines(lines []string, idx int, region []string) []string {
+	block := slices.Clone(region)
+
+	if idx > 0 && strings.TrimSpace(lines[idx-1]) != "" {
+		block = append([]string{""}, block...)
+	}
+
+	if idx < len(lines) && strings.TrimSpace(lines[idx]) != "" {
+		block = append(block, "")
+	}
+
+	return slices.Insert(lines, idx, block...)
+}
+
+// findHeading returns the index of the first heading whose text is title,
+// ignoring case, or -1
+func findHeading(lines []string, title string) int {
+	if title == "" {
+		return -1
+	}
+
+	for i, line := range lines {
+		if match := headingRe.FindStringSubmatch(strings.TrimSpace(line)); match != nil && strings.EqualFold(match[1], title) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// findInputsTable returns the range of lines holding the markdown table of
+// inputs generated by terraform-docs, which follows an "Inputs" heading and
+// starts with "Name" and "Description" columns
+func findInputsTable(lines []string) (int, int, bool) {
+	heading := findHeading(lines, "Inputs")
+	if heading < 0 {
+		return 0, 0, false
+	}
+
+	for i := heading + 1; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+
+		if headingRe.MatchString(line) {
+			break
+		}
+
+		if !strings.HasPrefix(line, "| Name | Description |") {
+			continue
+		}
+
+		end := i
+		for end < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[end]), "|") {
+			end++
+		}
+
+		return i, end, true
+	}
+
+	return 0, 0, false
+}
+
+// terraformDocsRegion returns the line of the terraform-docs end marker when the
+// line at idx is within the region generated by terraform-docs
+func terraformDocsRegion(lines []string, idx int) (int, bool) {
+	start, end := -1, -1
+
+	for i, line := range lines {
+		switch strings.TrimSpace(line) {
+		case terraformDocsMarkerStart:
+			if i < idx {
+				start = i
+			}
+		case terraformDocsMarkerEnd:
+			if i > idx && end < 0 {
+				end = i
+			}
+		}
+	}
+
+	return end, start >= 0 && end >= 0
+}
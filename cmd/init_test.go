@@ -0,0 +1,106 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInitReadme(t *testing.T) {
+	markers := markersConfig{Start: ExtrasMarkerStart, End: ExtrasMarkerEnd}
+	region := ExtrasMarkerStart + "\n" + ExtrasMarkerEnd + "\n"
+	inputsRegion := "<!-- TFDOCS_EXTRAS_START:inputs -->\n<!-- TFDOCS_EXTRAS_END:inputs -->\n"
+	inputsTable := "| Name | Description | Type | Default | Required |\n|------|-------------|------|---------|:--------:|\n| name | The name | `string` | n/a | yes |\n"
+
+	tests := map[string]struct {
+		content   string
+		placement initOptions
+		expected  string
+	}{
+		"after heading": {
+			content:   "# Module\n\n## Inputs\n\n## Outputs\n",
+			placement: initOptions{afterHeading: "inputs"},
+			expected:  "# Module\n\n## Inputs\n\n" + inputsRegion + "\n## Outputs\n",
+		},
+		"heading not found": {
+			content:   "# Module\n\nSome text\n\n",
+			placement: initOptions{afterHeading: "Inputs"},
+			expected:  "# Module\n\nSome text\n\n" + region,
+		},
+		"CRLF line endings": {
+			content:  "# Module\r\n",
+			expected: "# Module\r\n\r\n" + ExtrasMarkerStart + "\r\n" + ExtrasMarkerEnd + "\r\n",
+		},
+		"replace inputs table": {
+			content:   "## Inputs\n\n" + inputsTable + "\n## Outputs\n",
+			placement: initOptions{afterHeading: "Inputs", replaceInputs: true},
+			expected:  "## Inputs\n\n" + inputsRegion + "\n## Outputs\n",
+		},
+		"inputs table within terraform-docs region": {
+			content:   terraformDocsMarkerStart + "\n## Inputs\n\n" + inputsTable + terraformDocsMarkerEnd + "\n",
+			placement: initOptions{replaceInputs: true},
+			expected:  terraformDocsMarkerStart + "\n## Inputs\n\n" + inputsTable + terraformDocsMarkerEnd + "\n\n" + region,
+		},
+	}
+
+	for name, test := range tests {
+		actual, _ := initReadme(test.content, markers, test.placement)
+
+		if actual != test.expected {
+			t.Errorf("%s: expected:\n%q\ngot:\n%q", name, test.expected, actual)
+		}
+	}
+}
+
+func TestRun_InitThenGenerate(t *testing.T) {
+	dir := writeTestModule(t, "")
+	readmePath := filepath.Join(dir, "README.md")
+
+	if err := os.Remove(readmePath); err != nil {
+		t.Fatal(err)
+	}
+
+	if code, _, stderr := runCLI("init", dir); code != exitOK {
+		t.Fatalf("Expected init to succeed, got %d: %s", code, stderr)
+	}
+
+	if code, stdout, _ := runCLI("init", dir); code != exitOK || !strings.Contains(stdout, "already has markers") {
+		t.Errorf("Expected init to leave an initialized README alone (exit code %d): %s", code, stdout)
+	}
+
+	if code, _, stderr := runCLI("generate", dir); code != exitOK {
+		t.Fatalf("Expected generate to succeed after init, got %d: %s", code, stderr)
+	}
+
+	content, _ := os.ReadFile(readmePath)
+	if !strings.HasPrefix(string(content), "# "+filepath.Base(dir)+"\n\n"+ExtrasMarkerStart+"\n") {
+		t.Errorf("Unexpected README:\n%s", content)
+	}
+
+	if count := strings.Count(string(content), "## Inputs\n"); count != 1 {
+		t.Errorf("Expected the Inputs heading once, got %d times:\n%s", count, content)
+	}
+}
+
+func TestRun_InitUnderHeadingThenGenerate(t *testing.T) {
+	dir := writeTestModule(t, "# Module\n\n## Inputs\n\nConfigure the module with:\n\n## Outputs\n")
+	readmePath := filepath.Join(dir, "README.md")
+
+	if code, _, stderr := runCLI("init", dir); code != exitOK {
+		t.Fatalf("Expected init to succeed, got %d: %s", code, stderr)
+	}
+
+	if code, _, stderr := runCLI("generate", dir); code != exitOK {
+		t.Fatalf("Expected generate to succeed after init, got %d: %s", code, stderr)
+	}
+
+	content, _ := os.ReadFile(readmePath)
+	if count := strings.Count(string(content), "## Inputs\n"); count != 1 {
+		t.Errorf("Expected the Inputs heading once, got %d times:\n%s", count, content)
+	}
+
+	if !strings.Contains(string(content), "### Required\n") {
+		t.Errorf("Expected the inputs to be rendered:\n%s", content)
+	}
+}
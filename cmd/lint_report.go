@@ -0,0 +1,164 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/FriendsOfTerraform/tfdocs-extras"
+)
+
+// lintFormats lists the output formats of the lint command
+var lintFormats = []string{"text", "json", "sarif"}
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// lintExitCode fails on errors, or on any diagnostic in strict mode
+func lintExitCode(diagnostics []tfdocextras.Diagnostic, strict bool) int {
+	for _, diagnostic := range diagnostics {
+		if strict || diagnostic.Severity == tfdocextras.SeverityError {
+			return exitFailure
+		}
+	}
+
+	return exitOK
+}
+
+// writeLintReport writes diagnostics in one of the structured lintFormats
+func writeLintReport(format string, diagnostics []tfdocextras.Diagnostic, w io.Writer) error {
+	// Order the diagnostics of modules processed concurrently
+	slices.SortStableFunc(diagnostics, func(a, b tfdocextras.Diagnostic) int {
+		if c := strings.Compare(a.Filename, b.Filename); c != 0 {
+			return c
+		}
+
+		return a.Line - b.Line
+	})
+
+	var report any = diagnostics
+	if format == "sarif" {
+		report = newSarifLog(diagnostics)
+	} else if diagnostics == nil {
+		report = []tfdocextras.Diagnostic{}
+	}
+
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "%s\n", content)
+
+	return err
+}
+
+// The subset of the SARIF 2.1.0 format code scanning tools rely on
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+func newSarifLog(diagnostics []tfdocextras.Diagnostic) sarifLog {
+	driver := sarifDriver{
+		Name:           "tfdocs-extra",
+		Version:        version,
+		InformationURI: "https://github.com/FriendsOfTerraform/tfdocs-extras",
+		Rules:          []sarifRule{},
+	}
+
+	for _, rule := range tfdocextras.Rules() {
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   rule.Code,
+			ShortDescription:     sarifMessage{Text: rule.Description},
+			DefaultConfiguration: sarifConfiguration{Level: string(rule.Severity)},
+		})
+	}
+
+	results := []sarifResult{}
+	for _, diagnostic := range diagnostics {
+		result := sarifResult{
+			RuleID:  diagnostic.Code,
+			Level:   string(diagnostic.Severity),
+			Message: sarifMessage{Text: diagnostic.Path + ": " + diagnostic.Message},
+		}
+
+		if diagnostic.Filename != "" {
+			location := sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(diagnostic.Filename)},
+			}
+
+			if diagnostic.Line > 0 {
+				location.Region = &sarifRegion{StartLine: diagnostic.Line}
+			}
+
+			result.Locations = []sarifLocation{{PhysicalLocation: location}}
+		}
+
+		results = append(results, result)
+	}
+
+	return sarifLog{
+		Schema:  sarifSchema,
+		Version: "2.1.0",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+}
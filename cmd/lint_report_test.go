@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/FriendsOfTerraform/tfdocs-extras"
+	"github.com/go-test/deep"
+)
+
+func TestWriteLintReport_SARIF(t *testing.T) {
+	diagnostics := []tfdocextras.Diagnostic{
+		{Severity: tfdocextras.SeverityWarning, Code: tfdocextras.DiagUndocumented, Path: "name", Message: "variable has no description", Filename: "modules/efs/variables.tf", Line: 3},
+		{Severity: tfdocextras.SeverityError, Code: tfdocextras.DiagInvalidDirective, Path: "id", Message: "invalid", Filename: "modules/efs/variables.tf", Line: 1},
+	}
+
+	var output bytes.Buffer
+	if err := writeLintReport("sarif", diagnostics, &output); err != nil {
+		t.Fatal(err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(output.Bytes(), &log); err != nil {
+		t.Fatalf("Invalid SARIF: %v\n%s", err, output.String())
+	}
+
+	if len(log.Runs) != 1 || len(log.Runs[0].Tool.Driver.Rules) != len(tfdocextras.Rules()) {
+		t.Fatalf("Expected a single run describing every rule:\n%s", output.String())
+	}
+
+	// Results are ordered by location
+	expected := sarifResult{
+		RuleID:  tfdocextras.DiagInvalidDirective,
+		Level:   "error",
+		Message: sarifMessage{Text: "id: invalid"},
+		Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: "modules/efs/variables.tf"},
+			Region:           &sarifRegion{StartLine: 1},
+		}}},
+	}
+
+	if diff := deep.Equal(log.Runs[0].Results[0], expected); diff != nil {
+		t.Errorf("Result mismatch:\n%v", diff)
+	}
+}
@@ -1,115 +1,205 @@
 package main
 
 import (
-	"bytes"
-	"embed"
+	"errors"
+	"flag"
 	"fmt"
-	"log"
+	"io"
 	"os"
-	"path/filepath"
 	"strings"
-	"text/template"
+)
+
+// version is set at build time via `-ldflags "-X main.version=..."`
+var version = "dev"
 
-	"github.com/FriendsOfTerraform/tfdocs-extras"
-	"github.com/terraform-docs/terraform-docs/print"
-	"github.com/terraform-docs/terraform-docs/terraform"
+// Exit codes shared by every command
+const (
+	exitOK      = 0 // the command succeeded
+	exitFailure = 1 // the docs are out of date or lint found problems
+	exitUsage   = 2 // invalid command, flag, or argument
+	exitError   = 3 // the module, README, or template could not be processed
 )
 
-//go:embed templates/inputs.tmpl
-var inputsTmplContent embed.FS
-
-const ExtrasMarkerStart = "<!-- TFDOCS_EXTRAS_START -->"
-const ExtrasMarkerEnd = "<!-- TFDOCS_EXTRAS_END -->"
-
-// ReplaceContentBetweenMarkers replaces content between startMarker and endMarker
-// Both markers must exist on their own lines
-func replaceContentBetweenMarkers(content, startMarker, endMarker, newContent string) string {
-	lines := strings.Split(content, "\n")
-	var result []string
-	insideMarkers := false
-	foundStart := false
-
-	for _, line := range lines {
-		trimmedLine := strings.TrimSpace(line)
-
-		if trimmedLine == startMarker {
-			result = append(result, line)
-			result = append(result, newContent)
-			insideMarkers = true
-			foundStart = true
-			continue
-		}
+// errUsage marks errors caused by invalid command line usage
+var errUsage = errors.New("usage error")
+
+// errDiagnostics marks failures caused by problems found in the doc blocks
+var errDiagnostics = errors.New("doc block problems")
+
+type command struct {
+	name        string
+	summary     string
+	run         func(args []string, stdout, stderr io.Writer) int
+	hideInUsage bool
+}
 
-		if trimmedLine == endMarker {
-			result = append(result, line)
-			insideMarkers = false
-			continue
+func commands() []command {
+	return []command{
+		{name: "init", summary: "Add the markers to the module's README, creating it if needed", run: runInit},
+		{name: "generate", summary: "Render the documentation into the module's README", run: runGenerate},
+		{name: "check", summary: "Exit non-zero when the README documentation is out of date", run: runCheck},
+		{name: "lint", summary: "Report problems in the module's doc blocks and directives", run: runLint},
+		{name: "coverage", summary: "Report the share of inputs and nested fields that are documented", run: runCoverage},
+		{name: "validate", summary: "Check a .tfvars file against the documented inputs", run: runValidate},
+		{name: "validations", summary: "Generate validation blocks enforcing the documented directives", run: runValidations},
+		{name: "example", summary: "Generate an example .tfvars file or module block setting the inputs", run: runExample},
+		{name: "diff", summary: "Report the changes between two revisions of a module, failing on breaking ones", run: runDiff},
+		{name: "changelog", summary: "Render the inputs added and deprecated in each version from @since and @deprecated", run: runChangelog},
+		{name: "json", summary: "Print the parsed inputs manifest, or a JSON Schema of the inputs", run: runJSON},
+		{name: "serve", summary: "Preview the rendered README in a browser as the module changes", run: runServe},
+		{name: "version", summary: "Print the version of this tool", run: runVersion},
+		{name: "help", summary: "Show this help", run: runHelp, hideInUsage: true},
+	}
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: tfdocs-extra <command> [flags] [module-path]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+
+	for _, cmd := range commands() {
+		if !cmd.hideInUsage {
+			fmt.Fprintf(w, "  %-12s %s\n", cmd.name, cmd.summary)
 		}
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "The module path defaults to the current directory. Run `tfdocs-extra <command> --help`")
+	fmt.Fprintln(w, "for the flags supported by a command.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Exit codes:")
+	fmt.Fprintf(w, "  %d  success\n", exitOK)
+	fmt.Fprintf(w, "  %d  documentation is out of date or lint found problems\n", exitFailure)
+	fmt.Fprintf(w, "  %d  invalid usage\n", exitUsage)
+	fmt.Fprintf(w, "  %d  the module, README, or template could not be processed\n", exitError)
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		printUsage(stderr)
+		return exitUsage
+	}
 
-		if !insideMarkers {
-			result = append(result, line)
+	switch args[0] {
+	case "-h", "-help", "--help":
+		printUsage(stdout)
+		return exitOK
+	case "-v", "-version", "--version":
+		return runVersion(nil, stdout, stderr)
+	}
+
+	for _, cmd := range commands() {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:], stdout, stderr)
 		}
 	}
 
-	if !foundStart {
-		log.Fatal("Could not find start marker in README.md")
+	// Earlier releases only accepted a module path, keep supporting that form
+	if !strings.HasPrefix(args[0], "-") {
+		if _, err := os.Stat(args[0]); err == nil {
+			return runGenerate(args, stdout, stderr)
+		}
 	}
 
-	return strings.Join(result, "\n")
+	fmt.Fprintf(stderr, "tfdocs-extra: unknown command %q\n\n", args[0])
+	printUsage(stderr)
+
+	return exitUsage
 }
 
-func main() {
-	modulePath := os.Args[1]
+// parseFlags parses flags that may appear before or after positional arguments
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
 
-	if modulePath == "" {
-		log.Fatal("Module path argument is required")
-	}
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
 
-	config := print.DefaultConfig()
-	config.ModuleRoot = modulePath
+			// The flag package already reported the problem along with the usage
+			return nil, errUsage
+		}
 
-	module, err := terraform.LoadWithOptions(config)
-	if err != nil {
-		log.Fatal(err)
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
 	}
+}
 
-	// Read the README.md file
-	readmePath := filepath.Join(modulePath, "README.md")
-	readmeContent, err := os.ReadFile(readmePath)
+// parseCommand parses the flags of a command that accepts an optional module path
+func parseCommand(fs *flag.FlagSet, args []string) (string, error) {
+	positional, err := parseFlags(fs, args)
 	if err != nil {
-		log.Fatalf("Failed to read README.md: %v", err)
+		return "", err
 	}
 
-	// Generate the template output
-	tmpl, err := template.New("inputs.tmpl").Funcs(template.FuncMap{
-		"indent": func(spaces int, str string) string {
-			return "\n" + strings.Repeat("  ", spaces)
-		},
-	}).ParseFS(inputsTmplContent, "templates/inputs.tmpl")
-	if err != nil {
-		panic(err)
+	switch len(positional) {
+	case 0:
+		return ".", nil
+	case 1:
+		return positional[0], nil
+	default:
+		return "", fmt.Errorf("%w: expected a single module path, got %d arguments", errUsage, len(positional))
 	}
+}
 
-	templateData := tfdocextras.ParseModuleInputsIntoManifest(module.Inputs)
-	var templateOutput bytes.Buffer
-	err = tmpl.Execute(&templateOutput, templateData)
-	if err != nil {
-		panic(err)
+// exitCodeFor reports err to stderr and returns the matching exit code
+func exitCodeFor(err error, stderr io.Writer) int {
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+
+	if err != errUsage {
+		fmt.Fprintf(stderr, "tfdocs-extra: %v\n", err)
 	}
 
-	// Replace content between HTML comments
-	updatedContent := replaceContentBetweenMarkers(
-		string(readmeContent),
-		ExtrasMarkerStart,
-		ExtrasMarkerEnd,
-		templateOutput.String(),
-	)
+	if errors.Is(err, errUsage) {
+		return exitUsage
+	}
 
-	// Write the updated content back to README.md
-	err = os.WriteFile(readmePath, []byte(updatedContent), 0644)
-	if err != nil {
-		log.Fatalf("Failed to write README.md: %v", err)
+	if errors.Is(err, errDiagnostics) {
+		return exitFailure
 	}
 
-	fmt.Println("README.md updated successfully")
+	return exitError
+}
+
+// flagWasSet reports whether the flag called name was set on the command line
+func flagWasSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		set = set || f.Name == name
+	})
+
+	return set
+}
+
+func newFlagSet(name, usage string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: tfdocs-extra %s\n\nFlags:\n", usage)
+		fs.PrintDefaults()
+	}
+
+	return fs
+}
+
+func runVersion(_ []string, stdout, _ io.Writer) int {
+	fmt.Fprintf(stdout, "tfdocs-extra %s\n", version)
+	return exitOK
+}
+
+func runHelp(_ []string, stdout, _ io.Writer) int {
+	printUsage(stdout)
+	return exitOK
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
 }
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/FriendsOfTerraform/tfdocs-extras"
+)
+
+const testVariables = `variable "name" {
+  type        = string
+  description = "The name of the resource"
+}
+`
+
+func writeTestModule(t *testing.T, readme string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "variables.tf"), []byte(testVariables), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte(readme), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+func runCLI(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun_Usage(t *testing.T) {
+	if code, _, stderr := runCLI(); code != exitUsage || !strings.Contains(stderr, "Usage:") {
+		t.Errorf("Expected usage with exit code %d, got %d: %s", exitUsage, code, stderr)
+	}
+
+	if code, _, _ := runCLI("unknown-command"); code != exitUsage {
+		t.Errorf("Expected exit code %d for an unknown command, got %d", exitUsage, code)
+	}
+
+	if code, _, _ := runCLI("generate", "--unknown-flag"); code != exitUsage {
+		t.Errorf("Expected exit code %d for an unknown flag, got %d", exitUsage, code)
+	}
+}
+
+func TestRun_Version(t *testing.T) {
+	if code, stdout, _ := runCLI("version"); code != exitOK || stdout != "tfdocs-extra dev\n" {
+		t.Errorf("Unexpected version output (exit code %d): %s", code, stdout)
+	}
+}
+
+func TestRun_GenerateThenCheck(t *testing.T) {
+	readme := "# Module\n\n" + ExtrasMarkerStart + "\n" + ExtrasMarkerEnd + "\n"
+	dir := writeTestModule(t, readme)
+
+	code, stdout, stderr := runCLI("check", dir)
+	if code != exitFailure {
+		t.Fatalf("Expected stale docs to exit with %d, got %d: %s", exitFailure, code, stderr)
+	}
+
+	if !strings.Contains(stdout, "+The name of the resource") {
+		t.Errorf("Expected a unified diff of the changes, got:\n%s", stdout)
+	}
+
+	if code, _, _ := runCLI("generate", "--check", dir); code != exitFailure {
+		t.Fatalf("Expected generate --check to exit with %d, got %d", exitFailure, code)
+	}
+
+	if unchanged, _ := os.ReadFile(filepath.Join(dir, "README.md")); string(unchanged) != readme {
+		t.Fatalf("Expected generate --check not to touch the README:\n%s", unchanged)
+	}
+
+	if code, _, stderr := runCLI("generate", dir); code != exitOK {
+		t.Fatalf("Expected generate to succeed, got %d: %s", code, stderr)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(dir, "README.md"))
+	if !strings.Contains(string(content), "The name of the resource") {
+		t.Errorf("Expected the README to contain the generated docs:\n%s", content)
+	}
+
+	if code, _, stderr := runCLI("check", dir); code != exitOK {
+		t.Errorf("Expected up to date docs to exit with %d, got %d: %s", exitOK, code, stderr)
+	}
+}
+
+func TestRun_GenerateInferredConstraints(t *testing.T) {
+	dir := writeTestModule(t, ExtrasMarkerStart+"\n"+ExtrasMarkerEnd+"\n")
+
+	variables := testVariables + `
+variable "environment" {
+  type        = string
+  description = "The environment of the deployment"
+
+  validation {
+    condition     = contains(["dev", "prod"], var.environment)
+    error_message = "The environment must be dev or prod."
+  }
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "variables.tf"), []byte(variables), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if code, _, stderr := runCLI("generate", dir); code != exitOK {
+		t.Fatalf("Expected generate to succeed, got %d: %s", code, stderr)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(dir, "README.md"))
+	if !strings.Contains(string(content), "**Allowed Values:** _(inferred from validation)_\n- `dev`\n- `prod`") {
+		t.Errorf("Expected the values allowed by the validation block:\n%s", content)
+	}
+
+	if !strings.Contains(string(content), "**Validation:**\n\nThe environment must be dev or prod.\n\n```hcl\ncontains([\"dev\", \"prod\"], var.environment)\n```") {
+		t.Errorf("Expected the validation block to be rendered:\n%s", content)
+	}
+}
+
+func TestRun_MissingModule(t *testing.T) {
+	if code, _, _ := runCLI("generate", filepath.Join(t.TempDir(), "missing")); code != exitError {
+		t.Errorf("Expected exit code %d for a missing module, got %d", exitError, code)
+	}
+}
+
+func TestRun_GenerateNamedRegions(t *testing.T) {
+	readme := "# Module\n\n" +
+		namedMarker(ExtrasMarkerStart, "required") + "\n" + namedMarker(ExtrasMarkerEnd, "required") + "\n\n" +
+		"Hand-written prose\n\n" +
+		namedMarker(ExtrasMarkerStart, "optional") + "\n" + namedMarker(ExtrasMarkerEnd, "optional") + "\n"
+	dir := writeTestModule(t, readme)
+
+	if code, _, stderr := runCLI("generate", dir); code != exitOK {
+		t.Fatalf("Expected generate to succeed, got %d: %s", code, stderr)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(dir, "README.md"))
+	required, optional, _ := strings.Cut(string(content), "Hand-written prose")
+
+	if !strings.Contains(required, "The name of the resource") || strings.Contains(required, "### Optional") {
+		t.Errorf("Expected the required region to contain only the required inputs:\n%s", required)
+	}
+
+	if !strings.Contains(optional, namedMarker(ExtrasMarkerEnd, "optional")) || strings.Contains(optional, "The name of the resource") {
+		t.Errorf("Expected the optional region to contain only the optional inputs:\n%s", optional)
+	}
+}
+
+func TestRun_GenerateUnknownRegion(t *testing.T) {
+	readme := namedMarker(ExtrasMarkerStart, "unknown") + "\n" + namedMarker(ExtrasMarkerEnd, "unknown") + "\n"
+	dir := writeTestModule(t, readme)
+
+	if code, _, stderr := runCLI("generate", dir); code != exitError || !strings.Contains(stderr, `"unknown"`) {
+		t.Errorf("Expected exit code %d for a region without a template block, got %d: %s", exitError, code, stderr)
+	}
+}
+
+func TestRun_GenerateTemplateOverridesBlock(t *testing.T) {
+	dir := writeTestModule(t, ExtrasMarkerStart+"\n"+ExtrasMarkerEnd+"\n")
+	templatePath := filepath.Join(dir, "docs.tmpl")
+
+	override := `{{define "table_row"}}- {{.Name | upper}}: {{.Description}}{{"\n"}}{{end}}`
+	if err := os.WriteFile(templatePath, []byte(override), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if code, _, stderr := runCLI("generate", "--template", templatePath, dir); code != exitOK {
+		t.Fatalf("Expected generate to succeed, got %d: %s", code, stderr)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(dir, "README.md"))
+	if !strings.Contains(string(content), "- NAME: The name of the resource") || !strings.Contains(string(content), "### Required") {
+		t.Errorf("Expected the built-in document around the overridden block:\n%s", content)
+	}
+}
+
+func TestRun_Recursive(t *testing.T) {
+	root := t.TempDir()
+	readme := ExtrasMarkerStart + "\n" + ExtrasMarkerEnd + "\n"
+
+	for _, dir := range []string{"aws/efs", "aws/route53", "aws/efs/examples/basic", ".terraform/modules/vendored"} {
+		path := filepath.Join(root, dir)
+		if err := os.MkdirAll(path, 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(filepath.Join(path, "variables.tf"), []byte(testVariables), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		// Examples have no README markers and are not modules to document
+		if dir != "aws/efs/examples/basic" {
+			if err := os.WriteFile(filepath.Join(path, "README.md"), []byte(readme), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	code, stdout, _ := runCLI("check", "--recursive", "--jobs", "2", root)
+	if code != exitFailure || !strings.Contains(stdout, "2 module(s): 0 ok, 2 failed, 0 error(s)") {
+		t.Fatalf("Expected both modules to be out of date (exit code %d):\n%s", code, stdout)
+	}
+
+	if code, stdout, _ := runCLI("generate", "--recursive", root); code != exitOK {
+		t.Fatalf("Expected generate to succeed, got %d:\n%s", code, stdout)
+	}
+
+	if code, stdout, _ := runCLI("check", "--recursive", root); code != exitOK || !strings.Contains(stdout, "2 module(s): 2 ok") {
+		t.Errorf("Expected every module to be up to date (exit code %d):\n%s", code, stdout)
+	}
+
+	vendored, _ := os.ReadFile(filepath.Join(root, ".terraform/modules/vendored/README.md"))
+	if string(vendored) != readme {
+		t.Errorf("Expected hidden directories to be skipped:\n%s", vendored)
+	}
+
+	if code, _, _ := runCLI("generate", "--recursive", "--output", "-", root); code != exitUsage {
+		t.Errorf("Expected --output to be rejected with --recursive, got exit code %d", code)
+	}
+}
+
+func TestRun_JSONSchema(t *testing.T) {
+	dir := writeTestModule(t, "")
+
+	code, stdout, stderr := runCLI("json", "--format", "schema", dir)
+	if code != exitOK {
+		t.Fatalf("Expected json to succeed, got %d: %s", code, stderr)
+	}
+
+	var schema tfdocextras.JSONSchema
+	if err := json.Unmarshal([]byte(stdout), &schema); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, stdout)
+	}
+
+	if name := schema.Properties["name"]; name == nil || name.Type != "string" || name.Description != "The name of the resource" {
+		t.Errorf("Unexpected schema of the name variable:\n%s", stdout)
+	}
+
+	if code, _, _ := runCLI("json", "--format", "yaml", dir); code != exitUsage {
+		t.Errorf("Expected an invalid format to be rejected, got exit code %d", code)
+	}
+}
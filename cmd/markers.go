@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// splitMarker splits a marker where the name of a region is inserted: before the
+// closing "-->" of an HTML comment marker, or at its end otherwise
+func splitMarker(marker string) (head, tail string) {
+	body, isComment := strings.CutSuffix(marker, "-->")
+	if !isComment {
+		return marker, ""
+	}
+
+	head = strings.TrimRight(body, " ")
+
+	return head, marker[len(head):]
+}
+
+// namedMarker returns the marker of a named region, such as
+// `<!-- TFDOCS_EXTRAS_START:required -->`. The unnamed region uses the marker
+// unchanged.
+func namedMarker(marker, name string) string {
+	if name == "" {
+		return marker
+	}
+
+	head, tail := splitMarker(marker)
+
+	return head + ":" + name + tail
+}
+
+// findRegionNames returns the names of the regions whose start markers appear in
+// lines, in order of appearance. The unnamed region is reported as "".
+func findRegionNames(lines []string, startMarker string) []string {
+	head, tail := splitMarker(startMarker)
+
+	var names []string
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+
+		name := ""
+		if line != startMarker {
+			rest, ok := strings.CutPrefix(line, head+":")
+			if !ok {
+				continue
+			}
+
+			if name, ok = strings.CutSuffix(rest, tail); !ok || name == "" {
+				continue
+			}
+		}
+
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// findMarkers returns the indexes of the lines holding startMarker and endMarker.
+// Each marker must appear exactly once, on its own line, and in order.
+func findMarkers(lines []string, startMarker, endMarker string) (int, int, error) {
+	var starts, ends []int
+
+	for i, line := range lines {
+		switch strings.TrimSpace(line) {
+		case startMarker:
+			starts = append(starts, i)
+		case endMarker:
+			ends = append(ends, i)
+		}
+	}
+
+	switch {
+	case len(starts) == 0:
+		return 0, 0, fmt.Errorf("could not find start marker %s", startMarker)
+	case len(starts) > 1:
+		return 0, 0, fmt.Errorf("start marker %s appears %d times (lines %s)", startMarker, len(starts), lineNumbers(starts))
+	case len(ends) == 0:
+		return 0, 0, fmt.Errorf("could not find end marker %s after start marker on line %d", endMarker, starts[0]+1)
+	case len(ends) > 1:
+		return 0, 0, fmt.Errorf("end marker %s appears %d times (lines %s)", endMarker, len(ends), lineNumbers(ends))
+	case ends[0] < starts[0]:
+		return 0, 0, fmt.Errorf("end marker on line %d appears before start marker on line %d", ends[0]+1, starts[0]+1)
+	}
+
+	return starts[0], ends[0], nil
+}
+
+func lineNumbers(indexes []int) string {
+	numbers := make([]string, len(indexes))
+	for i, idx := range indexes {
+		numbers[i] = fmt.Sprint(idx + 1)
+	}
+
+	return strings.Join(numbers, ", ")
+}
+
+// replaceContentBetweenMarkers replaces content between startMarker and endMarker.
+// Both markers must exist on their own lines; the line endings of content (LF
+// or CRLF) are used for newContent as well.
+func replaceContentBetweenMarkers(content, startMarker, endMarker, newContent string) (string, error) {
+	lines := strings.Split(content, "\n")
+
+	startIdx, endIdx, err := findMarkers(lines, startMarker, endMarker)
+	if err != nil {
+		return "", err
+	}
+
+	if strings.Contains(content, "\r\n") {
+		newContent = strings.ReplaceAll(strings.ReplaceAll(newContent, "\r\n", "\n"), "\n", "\r\n") + "\r"
+	}
+
+	result := make([]string, 0, len(lines)-(endIdx-startIdx)+2)
+	result = append(result, lines[:startIdx+1]...)
+	result = append(result, newContent)
+	result = append(result, lines[endIdx:]...)
+
+	return strings.Join(result, "\n"), nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestReplaceContentBetweenMarkers(t *testing.T) {
+	content := "# Title\n" + ExtrasMarkerStart + "\nold\ncontent\n" + ExtrasMarkerEnd + "\nfooter\n"
+	expected := "# Title\n" + ExtrasMarkerStart + "\nnew\n" + ExtrasMarkerEnd + "\nfooter\n"
+
+	actual, err := replaceContentBetweenMarkers(content, ExtrasMarkerStart, ExtrasMarkerEnd, "new")
+	if err != nil {
+		t.Fatalf("replaceContentBetweenMarkers failed: %v", err)
+	}
+
+	if actual != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, actual)
+	}
+}
+
+func TestReplaceContentBetweenMarkers_PreservesCRLF(t *testing.T) {
+	content := "# Title\r\n" + ExtrasMarkerStart + "\r\nold\r\n" + ExtrasMarkerEnd + "\r\nfooter\r\n"
+	expected := "# Title\r\n" + ExtrasMarkerStart + "\r\nnew\r\nlines\r\n" + ExtrasMarkerEnd + "\r\nfooter\r\n"
+
+	actual, err := replaceContentBetweenMarkers(content, ExtrasMarkerStart, ExtrasMarkerEnd, "new\nlines")
+	if err != nil {
+		t.Fatalf("replaceContentBetweenMarkers failed: %v", err)
+	}
+
+	if actual != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, actual)
+	}
+}
+
+func TestReplaceContentBetweenMarkers_InvalidMarkers(t *testing.T) {
+	tests := map[string]string{
+		"missing start":   "text\n" + ExtrasMarkerEnd + "\n",
+		"missing end":     ExtrasMarkerStart + "\nhand-written content\n",
+		"misspelled end":  ExtrasMarkerStart + "\n<!-- TFDOCS_EXTRA_END -->\n",
+		"duplicate start": ExtrasMarkerStart + "\n" + ExtrasMarkerStart + "\n" + ExtrasMarkerEnd + "\n",
+		"duplicate end":   ExtrasMarkerStart + "\n" + ExtrasMarkerEnd + "\n" + ExtrasMarkerEnd + "\n",
+		"out of order":    ExtrasMarkerEnd + "\n" + ExtrasMarkerStart + "\n",
+	}
+
+	for name, content := range tests {
+		if _, err := replaceContentBetweenMarkers(content, ExtrasMarkerStart, ExtrasMarkerEnd, "new"); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestNamedMarker(t *testing.T) {
+	if actual := namedMarker(ExtrasMarkerStart, "required"); actual != "<!-- TFDOCS_EXTRAS_START:required -->" {
+		t.Errorf("Unexpected named marker %q", actual)
+	}
+
+	if actual := namedMarker("[//]: # (docs)", "outputs"); actual != "[//]: # (docs):outputs" {
+		t.Errorf("Unexpected named marker %q", actual)
+	}
+}
+
+func TestFindRegionNames(t *testing.T) {
+	lines := []string{
+		"<!-- TFDOCS_EXTRAS_START:required -->",
+		"<!-- TFDOCS_EXTRAS_END:required -->",
+		"<!-- TFDOCS_EXTRAS_START -->",
+		"<!-- TFDOCS_EXTRAS_END -->",
+		"  <!-- TFDOCS_EXTRAS_START:outputs -->",
+		"<!-- TFDOCS_EXTRAS_START: -->",
+		"<!-- TFDOCS_EXTRAS_START:required -->",
+	}
+
+	if diff := deep.Equal(findRegionNames(lines, ExtrasMarkerStart), []string{"required", "", "outputs"}); diff != nil {
+		t.Errorf("Region names mismatch:\n%v", diff)
+	}
+}
+
+func TestWriteFileAtomic_PreservesModeAndBacksUp(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "README.md")
+
+	if err := os.WriteFile(path, []byte("original"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writeFileAtomic(path, []byte("updated"), true); err != nil {
+		t.Fatalf("writeFileAtomic failed: %v", err)
+	}
+
+	info, _ := os.Stat(path)
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected mode 0600 to be preserved, got %v", info.Mode().Perm())
+	}
+
+	if content, _ := os.ReadFile(path); string(content) != "updated" {
+		t.Errorf("Expected the file to be updated, got %q", content)
+	}
+
+	if backup, _ := os.ReadFile(path + ".bak"); string(backup) != "original" {
+		t.Errorf("Expected a backup of the original content, got %q", backup)
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp-") {
+			t.Errorf("Expected the temporary file to be cleaned up, found %s", entry.Name())
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// recursiveOptions holds the flags of the commands supporting monorepos
+type recursiveOptions struct {
+	enabled bool
+	jobs    int
+}
+
+func (o *recursiveOptions) register(fs *flag.FlagSet) {
+	fs.BoolVar(&o.enabled, "recursive", false, "process every module under the given path whose README has markers")
+	fs.IntVar(&o.jobs, "jobs", runtime.NumCPU(), "number of modules processed concurrently with --recursive")
+}
+
+func (o *recursiveOptions) validate(fs *flag.FlagSet) error {
+	if o.jobs < 1 {
+		return fmt.Errorf("%w: --jobs must be at least 1", errUsage)
+	}
+
+	// Paths given on the command line would be shared by every module
+	for _, name := range []string{"readme", "output", "coverage-badge"} {
+		if o.enabled && flagWasSet(fs, name) {
+			return fmt.Errorf("%w: --%s cannot be used with --recursive", errUsage, name)
+		}
+	}
+
+	return nil
+}
+
+// moduleCommand processes a single module with its own options, writing to
+// stdout and stderr, and returns an exit code
+type moduleCommand func(modulePath string, opts *renderOptions, stdout, stderr io.Writer) int
+
+// moduleResult is the outcome of running a moduleCommand in recursive mode
+type moduleResult struct {
+	path   string
+	code   int
+	stdout bytes.Buffer
+	stderr bytes.Buffer
+}
+
+// runRecursive runs cmd on every module found under root. Each module loads its
+// own configuration, overridden by the flags set in fs, and the modules are
+// processed concurrently by a bounded pool of workers. The output of every
+// module is printed in order, followed by a summary; the exit code is the worst
+// of all modules.
+func runRecursive(root string, fs *flag.FlagSet, opts *renderOptions, rec *recursiveOptions, cmd moduleCommand, stdout, stderr io.Writer) int {
+	modules, err := findModules(root, func(dir string) bool {
+		moduleOpts := *opts
+		if err := moduleOpts.load(fs, dir); err != nil {
+			// Let the command report the broken configuration
+			return true
+		}
+
+		return hasMarkers(moduleOpts.readme(dir), moduleOpts.Markers.Start)
+	})
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	if len(modules) == 0 {
+		fmt.Fprintf(stderr, "tfdocs-extra: no module with a README containing markers found under %s\n", root)
+		return exitError
+	}
+
+	results := runModules(modules, rec.jobs, func(dir string, stdout, stderr io.Writer) int {
+		moduleOpts := *opts
+		if err := moduleOpts.load(fs, dir); err != nil {
+			return exitCodeFor(err, stderr)
+		}
+
+		return cmd(dir, &moduleOpts, stdout, stderr)
+	})
+
+	return reportModules(results, stdout, stderr)
+}
+
+// findModules returns the directories under root holding Terraform files for
+// which include reports true. Hidden directories such as .terraform and .git
+// are skipped.
+func findModules(root string, include func(dir string) bool) ([]string, error) {
+	var modules []string
+
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !entry.IsDir() {
+			return nil
+		}
+
+		if path != root && strings.HasPrefix(entry.Name(), ".") {
+			return filepath.SkipDir
+		}
+
+		if tfFiles, _ := filepath.Glob(filepath.Join(path, "*.tf")); len(tfFiles) > 0 && include(path) {
+			modules = append(modules, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search %s for modules: %w", root, err)
+	}
+
+	return modules, nil
+}
+
+// hasMarkers reports whether the README at path contains a start marker,
+// whether unnamed or named
+func hasMarkers(path, startMarker string) bool {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	return len(findRegionNames(strings.Split(string(content), "\n"), startMarker)) > 0
+}
+
+// runModules runs process on every module using at most jobs goroutines and
+// returns the results in the order of modules
+func runModules(modules []string, jobs int, process func(dir string, stdout, stderr io.Writer) int) []moduleResult {
+	results := make([]moduleResult, len(modules))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(jobs, len(modules)) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				result := &results[i]
+				result.path = modules[i]
+				result.code = process(modules[i], &result.stdout, &result.stderr)
+			}
+		}()
+	}
+
+	for i := range modules {
+		indexes <- i
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// reportModules prints the output of every module followed by a summary, and
+// returns the worst exit code
+func reportModules(results []moduleResult, stdout, stderr io.Writer) int {
+	code := exitOK
+	counts := make(map[int]int)
+
+	for i := range results {
+		result := &results[i]
+
+		if result.stdout.Len() > 0 || result.stderr.Len() > 0 {
+			fmt.Fprintf(stdout, "==> %s\n", result.path)
+			_, _ = result.stdout.WriteTo(stdout)
+			_, _ = result.stderr.WriteTo(stderr)
+		}
+
+		counts[result.code]++
+
+		// Errors outrank failures, which outrank successes
+		switch {
+		case result.code == exitFailure && code == exitOK:
+			code = exitFailure
+		case result.code != exitOK && result.code != exitFailure:
+			code = exitError
+		}
+	}
+
+	fmt.Fprintln(stdout)
+	fmt.Fprintln(stdout, "Summary:")
+
+	for i := range results {
+		fmt.Fprintf(stdout, "  %-6s %s\n", moduleStatus(results[i].code), results[i].path)
+	}
+
+	fmt.Fprintf(stdout, "%d module(s): %d ok, %d failed, %d error(s)\n",
+		len(results), counts[exitOK], counts[exitFailure], len(results)-counts[exitOK]-counts[exitFailure])
+
+	return code
+}
+
+func moduleStatus(code int) string {
+	switch code {
+	case exitOK:
+		return "ok"
+	case exitFailure:
+		return "failed"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/FriendsOfTerraform/tfdocs-extras"
+	"github.com/terraform-docs/terraform-docs/print"
+	"github.com/terraform-docs/terraform-docs/terraform"
+)
+
+//go:embed templates/inputs.tmpl
+var inputsTmplContent embed.FS
+
+const ExtrasMarkerStart = "<!-- TFDOCS_EXTRAS_START -->"
+const ExtrasMarkerEnd = "<!-- TFDOCS_EXTRAS_END -->"
+
+// renderOptions holds the settings shared by every command that renders
+// documentation: the project configuration, overridden by the flags that were
+// set on the command line
+type renderOptions struct {
+	projectConfig
+
+	// flags receives the values of the command line flags
+	flags            projectConfig
+	hiddenDirectives string
+}
+
+// registerManifestFlags registers the flags controlling how the module is parsed
+func (o *renderOptions) registerManifestFlags(fs *flag.FlagSet) {
+	fs.StringVar(&o.flags.Sort, "sort", "name", "order of the inputs and outputs: name, required, type, or source")
+	fs.StringVar(&o.hiddenDirectives, "hidden-directives", "", "comma-separated directives to leave out of the documentation")
+	fs.StringVar(&o.flags.CurrentVersion, "current-version", "", "highlight inputs whose @since matches this version")
+}
+
+func (o *renderOptions) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.flags.Readme, "readme", "", "path to the README to update (default \"<module-path>/README.md\")")
+	fs.StringVar(&o.flags.Markers.Start, "start-marker", ExtrasMarkerStart, "line marking the start of the generated documentation")
+	fs.StringVar(&o.flags.Markers.End, "end-marker", ExtrasMarkerEnd, "line marking the end of the generated documentation")
+	fs.StringVar(&o.flags.Template, "template", "", "path to a template replacing or extending the built-in one")
+	fs.BoolVar(&o.flags.Strict, "strict", false, "fail when the doc blocks have any problem, including warnings")
+	o.registerManifestFlags(fs)
+}
+
+// load reads the configuration files applying to modulePath, then overrides
+// their settings with the flags set on the command line
+func (o *renderOptions) load(fs *flag.FlagSet, modulePath string) error {
+	config, err := loadConfig(modulePath)
+	if err == nil {
+		err = config.validate()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load the configuration: %w", err)
+	}
+
+	o.projectConfig = config
+
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "readme":
+			o.Readme = o.flags.Readme
+		case "output":
+			o.Output = o.flags.Output
+		case "start-marker":
+			o.Markers.Start = o.flags.Markers.Start
+		case "end-marker":
+			o.Markers.End = o.flags.Markers.End
+		case "template":
+			o.Template = o.flags.Template
+		case "strict":
+			o.Strict = o.flags.Strict
+		case "sort":
+			o.Sort = o.flags.Sort
+		case "hidden-directives":
+			o.HiddenDirectives = splitList(o.hiddenDirectives)
+		case "current-version":
+			o.CurrentVersion = o.flags.CurrentVersion
+		case "min-coverage":
+			o.MinCoverage = o.flags.MinCoverage
+		case "coverage-badge":
+			o.CoverageBadge = o.flags.CoverageBadge
+		}
+	})
+
+	if err := o.validate(); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+
+	return nil
+}
+
+func (o *renderOptions) readme(modulePath string) string {
+	if o.Readme != "" {
+		return o.Readme
+	}
+
+	return filepath.Join(modulePath, "README.md")
+}
+
+func (o *renderOptions) manifestOptions() tfdocextras.ManifestOptions {
+	options := tfdocextras.DefaultManifestOptions()
+	options.CurrentVersion = o.CurrentVersion
+	options.HiddenDirectives = o.HiddenDirectives
+
+	return options
+}
+
+// splitList splits a comma-separated flag value, ignoring empty elements
+func splitList(value string) []string {
+	var elems []string
+
+	for _, elem := range strings.Split(value, ",") {
+		if elem = strings.TrimSpace(elem); elem != "" {
+			elems = append(elems, elem)
+		}
+	}
+
+	return elems
+}
+
+// loadModule loads the Terraform module at modulePath with terraform-docs,
+// sorting its inputs and outputs
+func loadModule(modulePath string, opts *renderOptions) (*terraform.Module, error) {
+	config := print.DefaultConfig()
+	config.ModuleRoot = modulePath
+	config.Sort.Enabled = opts.Sort != "source"
+	config.Sort.By = opts.Sort
+
+	module, err := terraform.LoadWithOptions(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load module %s: %w", modulePath, err)
+	}
+
+	return module, nil
+}
+
+// loadManifest loads the Terraform module at modulePath and parses its inputs
+// and outputs
+func loadManifest(modulePath string, opts *renderOptions) (*tfdocextras.InputsManifest, error) {
+	module, err := loadModule(modulePath, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	options := opts.manifestOptions()
+	if err := readValidations(modulePath, &options); err != nil {
+		return nil, err
+	}
+
+	manifest := tfdocextras.ParseModuleInputsIntoManifestWithOptions(module.Inputs, options)
+	tfdocextras.ParseModuleOutputsIntoManifestWithOptions(module.Outputs, manifest, options)
+	manifest.DiagnoseReferenceLinks()
+
+	applyRuleSeverities(manifest, opts.Lint.Rules)
+
+	return manifest, nil
+}
+
+// applyRuleSeverities changes the severity of diagnostics to the one configured
+// for their rule, dropping the diagnostics of disabled rules
+func applyRuleSeverities(manifest *tfdocextras.InputsManifest, severities map[string]tfdocextras.DiagnosticSeverity) {
+	diagnostics := manifest.Diagnostics[:0]
+
+	for _, diagnostic := range manifest.Diagnostics {
+		if severity, ok := severities[diagnostic.Code]; ok {
+			diagnostic.Severity = severity
+		}
+
+		if diagnostic.Severity != tfdocextras.SeverityOff {
+			diagnostics = append(diagnostics, diagnostic)
+		}
+	}
+
+	manifest.Diagnostics = diagnostics
+}
+
+// checkDiagnostics prints the diagnostics of a manifest and, in strict mode,
+// fails when there is any
+func checkDiagnostics(manifest *tfdocextras.InputsManifest, opts *renderOptions, w io.Writer) error {
+	printDiagnostics(manifest, w)
+
+	if opts.Strict && len(manifest.Diagnostics) > 0 {
+		return fmt.Errorf("%w: %d found in strict mode", errDiagnostics, len(manifest.Diagnostics))
+	}
+
+	return nil
+}
+
+func printDiagnostics(manifest *tfdocextras.InputsManifest, w io.Writer) {
+	for _, diagnostic := range manifest.Diagnostics {
+		fmt.Fprintln(w, diagnostic)
+	}
+}
+
+// loadTemplate parses the built-in template, then the template at templatePath
+// if any. The user template may replace the whole document, redefine individual
+// blocks such as `table_row` and `doc_summary`, or both; a file containing only
+// `define` actions keeps the built-in document around the redefined blocks.
+func loadTemplate(templatePath string) (*template.Template, error) {
+	tmpl, err := template.New("inputs.tmpl").Funcs(templateFuncs()).ParseFS(inputsTmplContent, "templates/inputs.tmpl")
+	if err != nil {
+		return nil, err
+	}
+
+	if templatePath == "" {
+		return tmpl, nil
+	}
+
+	content, err := os.ReadFile(templatePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+
+	if _, err := tmpl.New("inputs.tmpl").Parse(string(content)); err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", templatePath, err)
+	}
+
+	return tmpl, nil
+}
+
+// renderRegion executes the template block named after a README region against
+// a manifest; the unnamed region receives the output of the whole template
+func renderRegion(tmpl *template.Template, manifest *tfdocextras.InputsManifest, name string) (string, error) {
+	var output bytes.Buffer
+
+	if name == "" {
+		if err := tmpl.Execute(&output, manifest); err != nil {
+			return "", fmt.Errorf("failed to render template: %w", err)
+		}
+
+		return output.String(), nil
+	}
+
+	if tmpl.Lookup(name) == nil {
+		return "", fmt.Errorf("the template has no %q block for the README region of the same name", name)
+	}
+
+	if err := tmpl.ExecuteTemplate(&output, name, manifest); err != nil {
+		return "", fmt.Errorf("failed to render template block %q: %w", name, err)
+	}
+
+	return output.String(), nil
+}
+
+// renderReadme renders the module's documentation and returns the README's
+// current content along with its updated content. Each region of the README,
+// whether unnamed or named such as `<!-- TFDOCS_EXTRAS_START:required -->`, is
+// replaced with the output of the matching template block.
+func renderReadme(modulePath string, opts *renderOptions, stderr io.Writer) (current string, updated string, err error) {
+	manifest, err := loadManifest(modulePath, opts)
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := checkDiagnostics(manifest, opts, stderr); err != nil {
+		return "", "", err
+	}
+
+	tmpl, err := loadTemplate(opts.Template)
+	if err != nil {
+		return "", "", err
+	}
+
+	readmePath := opts.readme(modulePath)
+	content, err := os.ReadFile(readmePath)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read %s: %w", readmePath, err)
+	}
+
+	names := findRegionNames(strings.Split(string(content), "\n"), opts.Markers.Start)
+	if len(names) == 0 {
+		// Report the missing markers of the default region
+		names = []string{""}
+	}
+
+	updated = string(content)
+	for _, name := range names {
+		rendered, err := renderRegion(tmpl, manifest, name)
+		if err != nil {
+			return "", "", err
+		}
+
+		updated, err = replaceContentBetweenMarkers(updated, namedMarker(opts.Markers.Start, name), namedMarker(opts.Markers.End, name), rendered)
+		if err != nil {
+			return "", "", fmt.Errorf("%s: %w", readmePath, err)
+		}
+	}
+
+	return string(content), updated, nil
+}
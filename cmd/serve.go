@@ -0,0 +1,254 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+)
+
+//go:embed templates/preview.html
+var previewTmplContent string
+
+var previewTmpl = template.Must(template.New("preview.html").Parse(previewTmplContent))
+
+// markdown renders README files the way GitHub does closely enough for a
+// preview: GitHub flavored markdown, heading anchors, and raw HTML
+var markdown = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+	goldmark.WithRendererOptions(html.WithUnsafe()),
+)
+
+// previewPage is the data of the preview.html template
+type previewPage struct {
+	Title       string
+	Content     template.HTML
+	Error       string
+	Diagnostics []string
+}
+
+// reloadBroadcaster notifies every connected browser that the preview changed
+type reloadBroadcaster struct {
+	mu      sync.Mutex
+	clients map[chan struct{}]struct{}
+}
+
+func newReloadBroadcaster() *reloadBroadcaster {
+	return &reloadBroadcaster{clients: make(map[chan struct{}]struct{})}
+}
+
+func (b *reloadBroadcaster) subscribe() chan struct{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	client := make(chan struct{}, 1)
+	b.clients[client] = struct{}{}
+
+	return client
+}
+
+func (b *reloadBroadcaster) unsubscribe(client chan struct{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.clients, client)
+}
+
+func (b *reloadBroadcaster) notify() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for client := range b.clients {
+		// A reload is already pending for clients whose buffer is full
+		select {
+		case client <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// newPreviewHandler serves the module's README, with the generated
+// documentation rendered in memory, as HTML at "/", reload notifications as
+// server-sent events at "/events", and the other files of the module, such as
+// images referenced by the README, as they are
+func newPreviewHandler(modulePath string, load func() (*renderOptions, error), reloads *reloadBroadcaster) http.Handler {
+	mux := http.NewServeMux()
+	files := http.FileServer(http.Dir(modulePath))
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			files.ServeHTTP(w, r)
+			return
+		}
+
+		var page bytes.Buffer
+		if err := previewTmpl.Execute(&page, renderPreview(modulePath, load)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		_, _ = page.WriteTo(w)
+	})
+
+	mux.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+			return
+		}
+
+		client := reloads.subscribe()
+		defer reloads.unsubscribe(client)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-store")
+		flusher.Flush()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-client:
+				fmt.Fprint(w, "event: reload\ndata: \n\n")
+				flusher.Flush()
+			}
+		}
+	})
+
+	return mux
+}
+
+// renderPreview renders the README of a module the way generate would write it.
+// When the documentation cannot be generated, the README is shown unchanged
+// along with the error.
+func renderPreview(modulePath string, load func() (*renderOptions, error)) previewPage {
+	page := previewPage{Title: filepath.Base(modulePath)}
+
+	opts, err := load()
+	if err != nil {
+		page.Error = err.Error()
+		return page
+	}
+
+	var diagnostics bytes.Buffer
+	_, updated, err := renderReadme(modulePath, opts, &diagnostics)
+
+	page.Diagnostics = strings.FieldsFunc(diagnostics.String(), func(r rune) bool { return r == '\n' })
+
+	if err != nil {
+		page.Error = err.Error()
+
+		content, readErr := os.ReadFile(opts.readme(modulePath))
+		if readErr != nil {
+			return page
+		}
+
+		updated = string(content)
+	}
+
+	var content bytes.Buffer
+	if err := markdown.Convert([]byte(updated), &content); err != nil {
+		page.Error = fmt.Sprintf("failed to render markdown: %v", err)
+		return page
+	}
+
+	page.Content = template.HTML(content.String())
+
+	return page
+}
+
+func runServe(args []string, stdout, stderr io.Writer) int {
+	var opts renderOptions
+	var addr string
+
+	fs := newFlagSet("serve", "serve [flags] [module-path]", stderr)
+	opts.register(fs)
+	fs.StringVar(&addr, "addr", "localhost:8080", "address to listen on")
+
+	modulePath, err := parseCommand(fs, args)
+	if err == nil {
+		err = opts.load(fs, modulePath)
+	}
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	load := func() (*renderOptions, error) {
+		// Reload the configuration for every page so that its changes show up too
+		pageOpts := opts
+		if err := pageOpts.load(fs, modulePath); err != nil {
+			return nil, err
+		}
+
+		return &pageOpts, nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	reloads := newReloadBroadcaster()
+	server := &http.Server{
+		Handler:           newPreviewHandler(modulePath, load, reloads),
+		ReadHeaderTimeout: 10 * time.Second,
+		// Ends the event streams of connected browsers on shutdown
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+
+	fmt.Fprintf(stdout, "Serving the preview of %s at http://%s\n", modulePath, listener.Addr())
+
+	watchErr := make(chan error, 1)
+	go func() {
+		watchErr <- watchModule(ctx, modulePath, opts.Template, reloads.notify, stdout, stderr)
+	}()
+
+	select {
+	case err = <-serveErr:
+	case err = <-watchErr:
+	case <-ctx.Done():
+	}
+
+	stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if shutdownErr := server.Shutdown(shutdownCtx); err == nil {
+		err = shutdownErr
+	}
+
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return exitCodeFor(err, stderr)
+	}
+
+	return exitOK
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestPreviewServer(t *testing.T, readme string) (*httptest.Server, *reloadBroadcaster) {
+	t.Helper()
+
+	dir := writeTestModule(t, readme)
+	reloads := newReloadBroadcaster()
+
+	load := func() (*renderOptions, error) {
+		opts := &renderOptions{projectConfig: defaultConfig()}
+		return opts, nil
+	}
+
+	server := httptest.NewServer(newPreviewHandler(dir, load, reloads))
+	t.Cleanup(server.Close)
+
+	return server, reloads
+}
+
+func getPreview(t *testing.T, url string) string {
+	t.Helper()
+
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+
+	return string(body)
+}
+
+func TestPreviewHandler_RendersReadme(t *testing.T) {
+	server, _ := newTestPreviewServer(t, "# Module\n\n"+ExtrasMarkerStart+"\n"+ExtrasMarkerEnd+"\n")
+
+	page := getPreview(t, server.URL)
+
+	for _, expected := range []string{`<h1 id="module">Module</h1>`, "<td width=\"100%\">name</td>", "The name of the resource", `new EventSource("/events")`} {
+		if !strings.Contains(page, expected) {
+			t.Errorf("Expected the preview to contain %q:\n%s", expected, page)
+		}
+	}
+
+	if strings.Contains(page, `id="diagnostics"`) {
+		t.Errorf("Expected no diagnostics overlay:\n%s", page)
+	}
+
+	if readme := getPreview(t, server.URL+"/README.md"); !strings.Contains(readme, "# Module") {
+		t.Errorf("Expected the module's files to be served, got:\n%s", readme)
+	}
+}
+
+func TestPreviewHandler_ShowsErrors(t *testing.T) {
+	server, _ := newTestPreviewServer(t, "# Module without markers\n")
+
+	page := getPreview(t, server.URL)
+
+	if !strings.Contains(page, `<div id="diagnostics" class="error">`) || !strings.Contains(page, "could not find start marker") {
+		t.Errorf("Expected the error in an overlay:\n%s", page)
+	}
+
+	if !strings.Contains(page, "Module without markers") {
+		t.Errorf("Expected the README to be shown unchanged:\n%s", page)
+	}
+}
+
+func TestPreviewHandler_SendsReloadEvents(t *testing.T) {
+	server, reloads := newTestPreviewServer(t, ExtrasMarkerStart+"\n"+ExtrasMarkerEnd+"\n")
+
+	resp, err := http.Get(server.URL + "/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if contentType := resp.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Fatalf("Unexpected content type %q", contentType)
+	}
+
+	events := make(chan string)
+	go func() {
+		line, _ := bufio.NewReader(resp.Body).ReadString('\n')
+		events <- line
+	}()
+
+	// The client subscribes before the response headers are sent
+	reloads.notify()
+
+	select {
+	case line := <-events:
+		if line != "event: reload\n" {
+			t.Errorf("Unexpected event %q", line)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the reload event")
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/FriendsOfTerraform/tfdocs-extras"
+)
+
+func runValidate(args []string, stdout, stderr io.Writer) int {
+	var opts renderOptions
+	var varFile string
+
+	fs := newFlagSet("validate", "validate [flags] --var-file <file> [module-path]", stderr)
+	fs.StringVar(&varFile, "var-file", "", "path to the .tfvars or .tfvars.json file to validate")
+
+	modulePath, err := parseCommand(fs, args)
+	if err == nil {
+		err = opts.load(fs, modulePath)
+	}
+	if err == nil && varFile == "" {
+		err = fmt.Errorf("%w: --var-file is required", errUsage)
+	}
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	content, err := os.ReadFile(varFile)
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	module, err := loadModule(modulePath, &opts)
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	valueErrors, err := tfdocextras.ValidateVariablesFile(module.Inputs, varFile, content)
+	if err != nil {
+		return exitCodeFor(fmt.Errorf("failed to parse %s: %w", varFile, err), stderr)
+	}
+
+	for _, valueErr := range valueErrors {
+		fmt.Fprintln(stdout, valueErr)
+	}
+
+	if len(valueErrors) > 0 {
+		return exitFailure
+	}
+
+	fmt.Fprintf(stdout, "%s is valid\n", varFile)
+
+	return exitOK
+}
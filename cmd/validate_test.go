@@ -0,0 +1,33 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun_Validate(t *testing.T) {
+	dir := writeTestModule(t, "")
+	varFile := filepath.Join(dir, "terraform.tfvars")
+
+	if err := os.WriteFile(varFile, []byte("name = \"efs\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if code, stdout, stderr := runCLI("validate", "--var-file", varFile, dir); code != exitOK || !strings.Contains(stdout, "is valid") {
+		t.Errorf("Expected the file to be valid, got %d: %s%s", code, stdout, stderr)
+	}
+
+	if err := os.WriteFile(varFile, []byte("name = { id = 1 }\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if code, stdout, _ := runCLI("validate", "--var-file", varFile, dir); code != exitFailure || !strings.Contains(stdout, "name: expected string, got object [type-mismatch]") {
+		t.Errorf("Expected a type mismatch, got %d: %s", code, stdout)
+	}
+
+	if code, _, _ := runCLI("validate", dir); code != exitUsage {
+		t.Errorf("Expected --var-file to be required, got exit code %d", code)
+	}
+}
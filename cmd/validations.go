@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/FriendsOfTerraform/tfdocs-extras"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+const validationsHeader = "# Code generated by tfdocs-extra from the @enum, @regex, and @key directives. DO NOT EDIT.\n"
+
+func runValidations(args []string, stdout, stderr io.Writer) int {
+	var opts renderOptions
+	var output string
+
+	fs := newFlagSet("validations", "validations [flags] [module-path]", stderr)
+	fs.StringVar(&output, "output", "-", "write the validations to this override file (e.g. validations_override.tf), or \"-\" for stdout")
+
+	modulePath, err := parseCommand(fs, args)
+	if err == nil {
+		err = opts.load(fs, modulePath)
+	}
+	if err == nil && output != "-" && !isOverrideFile(output) {
+		err = fmt.Errorf("%w: --output must be a Terraform override file, named override.tf or ending with _override.tf", errUsage)
+	}
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	module, err := loadModule(modulePath, &opts)
+	if err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	validations := tfdocextras.GenerateValidations(module.Inputs)
+
+	// Override files replace every validation block of the variables they
+	// declare, keep those written by hand
+	var handWritten map[string][]string
+	if output != "-" {
+		if handWritten, err = handWrittenValidations(modulePath); err != nil {
+			return exitCodeFor(err, stderr)
+		}
+	}
+
+	if err := writeOutput(output, renderValidations(validations, handWritten), false, stdout); err != nil {
+		return exitCodeFor(err, stderr)
+	}
+
+	if output != "-" {
+		fmt.Fprintf(stdout, "%s updated successfully\n", output)
+	}
+
+	return exitOK
+}
+
+// isOverrideFile reports whether Terraform merges the file at path into the
+// other files of its module
+func isOverrideFile(path string) bool {
+	name := filepath.Base(path)
+
+	return name == "override.tf" || strings.HasSuffix(name, "_override.tf")
+}
+
+// configFiles returns the configuration files of a module, leaving out override
+// files such as the one written by the validations command
+func configFiles(modulePath string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(modulePath, "*.tf"))
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(paths, isOverrideFile), nil
+}
+
+// handWrittenValidations returns the source of the validation blocks declared in
+// the configuration files of a module, by variable
+func handWrittenValidations(modulePath string) (map[string][]string, error) {
+	paths, err := configFiles(modulePath)
+	if err != nil {
+		return nil, err
+	}
+
+	validations := map[string][]string{}
+
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		file, diags := hclsyntax.ParseConfig(content, path, hcl.InitialPos)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+			if block.Type != "variable" || len(block.Labels) != 1 {
+				continue
+			}
+
+			for _, nested := range block.Body.Blocks {
+				if nested.Type == "validation" {
+					rng := nested.Range()
+					validations[block.Labels[0]] = append(validations[block.Labels[0]], string(rng.SliceBytes(content)))
+				}
+			}
+		}
+	}
+
+	return validations, nil
+}
+
+// readValidations records in options the validation blocks of the configuration
+// files of a module, along with the constraints recognized in them
+func readValidations(modulePath string, options *tfdocextras.ManifestOptions) error {
+	paths, err := configFiles(modulePath)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		validations, err := tfdocextras.ParseValidations(path, content)
+		if err != nil {
+			return err
+		}
+
+		constraints, err := tfdocextras.InferConstraints(path, content)
+		if err != nil {
+			return err
+		}
+
+		options.Validations = append(options.Validations, validations...)
+		options.InferredConstraints = append(options.InferredConstraints, constraints...)
+	}
+
+	return nil
+}
+
+// renderValidations renders the validations grouped in variable blocks, after
+// the hand-written validations of those variables
+func renderValidations(validations []tfdocextras.VariableValidation, handWritten map[string][]string) []byte {
+	var content strings.Builder
+
+	content.WriteString(validationsHeader)
+
+	for i, validation := range validations {
+		if i == 0 || validations[i-1].Variable != validation.Variable {
+			if i > 0 {
+				content.WriteString("}\n")
+			}
+
+			fmt.Fprintf(&content, "\nvariable %s {\n", strconv.Quote(validation.Variable))
+
+			for _, block := range handWritten[validation.Variable] {
+				content.WriteString(block + "\n\n")
+			}
+		} else {
+			content.WriteString("\n")
+		}
+
+		content.WriteString(validation.Block() + "\n")
+	}
+
+	if len(validations) > 0 {
+		content.WriteString("}\n")
+	}
+
+	return hclwrite.Format([]byte(content.String()))
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun_Validations(t *testing.T) {
+	dir := writeTestModule(t, "")
+
+	variables := `variable "mode" {
+  type        = string
+  description = "@enum a|b"
+
+  validation {
+    condition     = length(var.mode) > 0
+    error_message = "The mode must not be empty."
+  }
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "variables.tf"), []byte(variables), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	generated := `  validation {
+    condition     = var.mode == null ? true : contains(["a", "b"], var.mode)
+    error_message = "The value of var.mode must be one of \"a\", \"b\"."
+  }
+`
+
+	code, stdout, stderr := runCLI("validations", dir)
+	if code != exitOK || !strings.Contains(stdout, generated) || strings.Contains(stdout, "length(var.mode)") {
+		t.Fatalf("Expected the generated validation only, got %d: %s%s", code, stdout, stderr)
+	}
+
+	// The override file keeps the validations written by hand
+	output := filepath.Join(dir, "validations_override.tf")
+	if code, _, stderr := runCLI("validations", "--output", output, dir); code != exitOK {
+		t.Fatalf("Expected validations to succeed, got %d: %s", code, stderr)
+	}
+
+	content, _ := os.ReadFile(output)
+	if !strings.Contains(string(content), "length(var.mode) > 0") || !strings.Contains(string(content), generated) {
+		t.Errorf("Expected the hand-written and generated validations:\n%s", content)
+	}
+
+	if code, _, _ := runCLI("validations", "--output", filepath.Join(dir, "validations.tf"), dir); code != exitUsage {
+		t.Errorf("Expected files other than override files to be rejected, got exit code %d", code)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is how long the watcher waits for a burst of events, such as
+// an editor saving several files, to settle before regenerating
+const watchDebounce = 200 * time.Millisecond
+
+// watchModule runs regenerate once, then again whenever a `.tf` file or the
+// configuration of the module, or the template, changes, until ctx is done.
+// Failures are reported by regenerate and never stop the watcher.
+func watchModule(ctx context.Context, modulePath, templatePath string, regenerate func(), stdout, stderr io.Writer) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to start watching: %w", err)
+	}
+	defer watcher.Close()
+
+	moduleDir, err := filepath.Abs(modulePath)
+	if err != nil {
+		return err
+	}
+
+	watched := func(path string) bool {
+		path, _ = filepath.Abs(path)
+
+		if filepath.Dir(path) == moduleDir {
+			name := filepath.Base(path)
+			if strings.HasSuffix(name, ".tf") || name == configFileName {
+				return true
+			}
+		}
+
+		return templatePath != "" && path == templatePath
+	}
+
+	dirs := []string{moduleDir}
+	if templatePath != "" {
+		templatePath, _ = filepath.Abs(templatePath)
+		dirs = append(dirs, filepath.Dir(templatePath))
+	}
+
+	// Directories are watched rather than files so that editors replacing a
+	// file on save are noticed
+	for _, dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", dir, err)
+		}
+	}
+
+	regenerate()
+	fmt.Fprintf(stdout, "Watching %s for changes, press Ctrl+C to stop\n", modulePath)
+
+	debounce := time.NewTimer(watchDebounce)
+	debounce.Stop()
+
+	var changed []string
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-watcher.Events:
+			if event.Op == fsnotify.Chmod || !watched(event.Name) {
+				continue
+			}
+
+			if !slices.Contains(changed, filepath.Base(event.Name)) {
+				changed = append(changed, filepath.Base(event.Name))
+			}
+
+			debounce.Reset(watchDebounce)
+		case err := <-watcher.Errors:
+			fmt.Fprintf(stderr, "tfdocs-extra: watch error: %v\n", err)
+		case <-debounce.C:
+			fmt.Fprintf(stdout, "[%s] %s changed\n", time.Now().Format(time.TimeOnly), strings.Join(changed, ", "))
+			changed = nil
+
+			regenerate()
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent use
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+func TestWatchModule_RegeneratesOnChange(t *testing.T) {
+	dir := t.TempDir()
+	runs := make(chan struct{}, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var stdout, stderr syncBuffer
+	done := make(chan error)
+
+	go func() {
+		done <- watchModule(ctx, dir, "", func() { runs <- struct{}{} }, &stdout, &stderr)
+	}()
+
+	waitForRun := func() {
+		t.Helper()
+
+		select {
+		case <-runs:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timed out waiting for a regeneration:\n%s", stdout.String())
+		}
+	}
+
+	// The initial generation
+	waitForRun()
+
+	// A burst of writes triggers a single regeneration
+	for i := range 3 {
+		if err := os.WriteFile(filepath.Join(dir, "variables.tf"), []byte(strings.Repeat("#\n", i+1)), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignored"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	waitForRun()
+
+	select {
+	case <-runs:
+		t.Errorf("Expected a single regeneration for a burst of events:\n%s", stdout.String())
+	case <-time.After(2 * watchDebounce):
+	}
+
+	if !strings.Contains(stdout.String(), "variables.tf changed") {
+		t.Errorf("Expected the changed file to be reported:\n%s", stdout.String())
+	}
+
+	cancel()
+
+	if err := <-done; err != nil {
+		t.Errorf("watchModule failed: %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+package tfdocextras
+
+import (
+	"strings"
+
+	"github.com/terraform-docs/terraform-docs/terraform"
+)
+
+// Coverage counts the documented fields among a set of fields
+type Coverage struct {
+	Fields       int      `json:"fields"`
+	Documented   int      `json:"documented"`
+	Percent      float64  `json:"percent"`
+	Undocumented []string `json:"undocumented,omitempty"`
+}
+
+// VariableCoverage is the coverage of a variable and its nested fields
+type VariableCoverage struct {
+	Name string `json:"name"`
+	Coverage
+}
+
+// ModuleCoverage is the coverage of every variable of a module
+type ModuleCoverage struct {
+	Coverage
+	Variables []VariableCoverage `json:"variables"`
+}
+
+// record counts a field at path as documented when its doc block has content
+func (c *Coverage) record(path string, content []string) {
+	c.Fields++
+
+	if strings.TrimSpace(strings.Join(content, "\n")) != "" {
+		c.Documented++
+	} else {
+		c.Undocumented = append(c.Undocumented, path)
+	}
+
+	c.Percent = 100 * float64(c.Documented) / float64(c.Fields)
+}
+
+// add merges the counts of other into c
+func (c *Coverage) add(other Coverage) {
+	c.Fields += other.Fields
+	c.Documented += other.Documented
+	c.Undocumented = append(c.Undocumented, other.Undocumented...)
+
+	if c.Fields > 0 {
+		c.Percent = 100 * float64(c.Documented) / float64(c.Fields)
+	}
+}
+
+// recordFields walks the nested fields of an object, depth first
+func (c *Coverage) recordFields(fields []ObjectField, path string) {
+	for _, field := range fields {
+		fieldPath := path + "." + field.Name
+
+		c.record(fieldPath, field.Documentation.Content)
+		c.recordFields(field.Fields, fieldPath)
+	}
+}
+
+// ComputeCoverage reports which of the inputs of a module loaded by
+// terraform-docs, and of the fields nested in their object types, have a
+// description. Directives alone do not document a field. A module without
+// inputs is fully covered.
+func ComputeCoverage(inputs []*terraform.Input) ModuleCoverage {
+	module := ModuleCoverage{
+		Coverage:  Coverage{Percent: 100},
+		Variables: []VariableCoverage{},
+	}
+
+	for _, input := range inputs {
+		variable := VariableCoverage{Name: input.Name}
+		variable.record(input.Name, parseStringIntoDocBlock(string(input.Description)).Content)
+
+		if input.Type != "" {
+			documented, err := ParseIntoDocumentedStruct(string(input.Type), input.Name)
+
+			if err == nil && documented != nil {
+				variable.recordFields(documented.Fields, input.Name)
+			}
+		}
+
+		module.add(variable.Coverage)
+		module.Variables = append(module.Variables, variable)
+	}
+
+	return module
+}
@@ -0,0 +1,55 @@
+package tfdocextras
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestComputeCoverage(t *testing.T) {
+	module := loadTestModule(t, "coverage")
+	actual := ComputeCoverage(module.Inputs)
+
+	expected := ModuleCoverage{
+		Coverage: Coverage{
+			Fields:     8,
+			Documented: 4,
+			Percent:    50,
+			Undocumented: []string{
+				"lifecycle_policy.transition_to_archive",
+				"lifecycle_policy.archive",
+				"lifecycle_policy.archive.retention_days",
+				"tags",
+			},
+		},
+		Variables: []VariableCoverage{
+			{
+				Name: "lifecycle_policy",
+				Coverage: Coverage{
+					Fields:     6,
+					Documented: 3,
+					Percent:    50,
+					Undocumented: []string{
+						"lifecycle_policy.transition_to_archive",
+						"lifecycle_policy.archive",
+						"lifecycle_policy.archive.retention_days",
+					},
+				},
+			},
+			{Name: "name", Coverage: Coverage{Fields: 1, Documented: 1, Percent: 100}},
+			{Name: "tags", Coverage: Coverage{Fields: 1, Percent: 0, Undocumented: []string{"tags"}}},
+		},
+	}
+
+	if diff := deep.Equal(actual, expected); diff != nil {
+		t.Errorf("Coverage mismatch:\n%v", diff)
+	}
+}
+
+func TestComputeCoverage_NoInputs(t *testing.T) {
+	actual := ComputeCoverage(nil)
+
+	if actual.Percent != 100 || actual.Fields != 0 {
+		t.Errorf("Expected a module without inputs to be fully covered, got %+v", actual)
+	}
+}
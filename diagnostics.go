@@ -0,0 +1,80 @@
+package tfdocextras
+
+import (
+	"fmt"
+	"slices"
+)
+
+type DiagnosticSeverity string
+
+const (
+	SeverityError   DiagnosticSeverity = "error"
+	SeverityWarning DiagnosticSeverity = "warning"
+
+	// SeverityOff disables a rule; it is never reported by a Diagnostic
+	SeverityOff DiagnosticSeverity = "off"
+)
+
+// Diagnostic codes identify the kind of problem a Diagnostic reports
+const (
+	DiagInvalidDirective       = "invalid-directive"
+	DiagMisplacedDirective     = "misplaced-directive"
+	DiagRegexExampleMismatch   = "regex-example-mismatch"
+	DiagUnknownDirective       = "unknown-directive"
+	DiagDuplicateDirective     = "duplicate-directive"
+	DiagUndocumented           = "undocumented"
+	DiagEnumDefaultMismatch    = "enum-default-mismatch"
+	DiagUndefinedReferenceLink = "undefined-reference-link"
+)
+
+// Rule describes a check whose problems are reported as diagnostics with its
+// code, along with the severity they are reported with
+type Rule struct {
+	Code        string
+	Severity    DiagnosticSeverity
+	Description string
+}
+
+var rules = []Rule{
+	{DiagInvalidDirective, SeverityError, "Directives must follow their documented syntax"},
+	{DiagUnknownDirective, SeverityWarning, "Directives must be supported by tfdocs-extras"},
+	{DiagDuplicateDirective, SeverityWarning, "Directives describing a single value must not be repeated"},
+	{DiagMisplacedDirective, SeverityWarning, "Directives must be used on fields of the type they document"},
+	{DiagRegexExampleMismatch, SeverityError, "Examples of @regex and @key must match their pattern"},
+	{DiagEnumDefaultMismatch, SeverityError, "Default values must be one of the @enum values"},
+	{DiagUndefinedReferenceLink, SeverityError, "Reference links must be defined with @link {id}"},
+	{DiagUndocumented, SeverityWarning, "Variables, nested fields, and outputs must have a description"},
+}
+
+// Rules returns every rule diagnostics are reported for
+func Rules() []Rule {
+	return slices.Clone(rules)
+}
+
+// isRuleCode reports whether code identifies one of the Rules
+func isRuleCode(code string) bool {
+	return slices.ContainsFunc(rules, func(rule Rule) bool {
+		return rule.Code == code
+	})
+}
+
+// Diagnostic describes a problem found in a module's documentation while
+// building an InputsManifest
+type Diagnostic struct {
+	Severity DiagnosticSeverity `json:"severity"`
+	Code     string             `json:"code"`
+	Path     string             `json:"path"`
+	Message  string             `json:"message"`
+	Filename string             `json:"filename,omitempty"`
+	Line     int                `json:"line,omitempty"`
+}
+
+func (d Diagnostic) String() string {
+	location := d.Path
+
+	if d.Filename != "" {
+		location = fmt.Sprintf("%s:%d: %s", d.Filename, d.Line, d.Path)
+	}
+
+	return fmt.Sprintf("%s: %s: %s [%s]", d.Severity, location, d.Message, d.Code)
+}
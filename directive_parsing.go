@@ -3,12 +3,12 @@ package tfdocextras
 import (
 	"regexp"
 	"strings"
+	"unicode"
 )
 
 var (
-	quoteAndUrlRe   = regexp.MustCompile(`^"([^"]+)"\s+(.+)$`)
-	braceAndUrlRe   = regexp.MustCompile(`^\{([^}]+)}\s+(.+)$`)
-	enumDelimiterRe = regexp.MustCompile(`\s*\|\s*`)
+	braceRe        = regexp.MustCompile(`^\{([^}]+)}$`)
+	patternStartRe = regexp.MustCompile(`\s/`)
 )
 
 type DirectiveType int
@@ -22,6 +22,10 @@ const (
 	DirRegex
 	DirSee
 	DirSince
+	DirInternal
+	DirStability
+	DirKey
+	DirLintIgnore
 )
 
 const (
@@ -35,18 +39,10 @@ type ParsedDirective struct {
 	Type  DirectiveType
 	Args  []string
 	Flags byte
-}
-
-func splitBySpacePreserveQuotes(s string) []string {
-	quoted := false
-
-	return strings.FieldsFunc(s, func(r rune) bool {
-		if r == '"' {
-			quoted = !quoted
-		}
 
-		return !quoted && r == ' '
-	})
+	// NamedArgs holds the optional named arguments (e.g. `description="..."`)
+	// a directive accepts in addition to its positional Args
+	NamedArgs map[string]string `json:",omitempty"`
 }
 
 func ParseDirective(name string, line string) ParsedDirective {
@@ -61,12 +57,20 @@ func ParseDirective(name string, line string) ParsedDirective {
 		return parseExampleDirective(line)
 	case "regex":
 		return parseRegexDirective(line)
+	case "key":
+		return parseKeyDirective(line)
 	case "deprecated":
 		return newBasicDirective(DirDeprecated, line)
 	case "see":
 		return newBasicDirective(DirSee, line)
 	case "since":
-		return newBasicDirective(DirSince, line)
+		return parseSinceDirective(line)
+	case "internal":
+		return newBasicDirective(DirInternal, line)
+	case "stability":
+		return newBasicDirective(DirStability, line)
+	case "lint-ignore":
+		return parseLintIgnoreDirective(line)
 	default:
 		return newInvalidDirective(DirUnsupported)
 	}
@@ -88,11 +92,48 @@ func newInvalidDirective(dt DirectiveType) ParsedDirective {
 	}
 }
 
+func parseSinceDirective(line string) ParsedDirective {
+	if _, err := ParseVersion(line); err != nil {
+		return newInvalidDirective(DirSince)
+	}
+
+	return newBasicDirective(DirSince, line)
+}
+
+// parseLintIgnoreDirective parses the codes of the rules a field suppresses,
+// separated by spaces or commas, or "all" to suppress every rule
+func parseLintIgnoreDirective(line string) ParsedDirective {
+	codes := strings.FieldsFunc(line, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+
+	if len(codes) == 0 {
+		return newInvalidDirective(DirLintIgnore)
+	}
+
+	for _, code := range codes {
+		if code != "all" && !isRuleCode(code) {
+			return newInvalidDirective(DirLintIgnore)
+		}
+	}
+
+	return ParsedDirective{
+		Type:  DirLintIgnore,
+		Args:  codes,
+		Flags: IsValid,
+	}
+}
+
 func parseExampleDirective(line string) ParsedDirective {
-	if matches := quoteAndUrlRe.FindStringSubmatch(line); len(matches) == 3 {
+	tokens, err := tokenizeDirective(line)
+	if err != nil {
+		return newInvalidDirective(DirExample)
+	}
+
+	if args, ok := bindDirectiveArgs(tokens, "title", "href"); ok {
 		return ParsedDirective{
 			Type:  DirExample,
-			Args:  matches[1:],
+			Args:  args,
 			Flags: IsValid,
 		}
 	}
@@ -100,8 +141,25 @@ func parseExampleDirective(line string) ParsedDirective {
 	return newInvalidDirective(DirExample)
 }
 
+// parseEnumDirective parses values separated by `|`. Quoted values may contain
+// `|` and are kept as written, quotes included, like unquoted ones.
 func parseEnumDirective(line string) ParsedDirective {
-	choices := enumDelimiterRe.Split(line, -1)
+	var choices []string
+
+	for {
+		token, rest, _, err := cutDirectiveTokenFunc(line, isEnumDelimiter)
+		if err != nil {
+			return newInvalidDirective(DirEnum)
+		}
+
+		choices = append(choices, token.Raw)
+
+		if rest == "" {
+			break
+		}
+
+		line = rest[1:]
+	}
 
 	return ParsedDirective{
 		Type:  DirEnum,
@@ -110,35 +168,112 @@ func parseEnumDirective(line string) ParsedDirective {
 	}
 }
 
+func isEnumDelimiter(r rune) bool {
+	return r == '|'
+}
+
 func parseLinkDirective(line string) ParsedDirective {
-	if strings.HasPrefix(line, "\"") {
-		if matches := quoteAndUrlRe.FindStringSubmatch(line); len(matches) == 3 {
-			return ParsedDirective{
-				Type:  DirLink,
-				Args:  matches[1:],
-				Flags: IsValid | IsNamedLink,
-			}
-		}
+	tokens, err := tokenizeDirective(line)
+	if err != nil || len(tokens) == 0 {
+		return newInvalidDirective(DirLink)
 	}
 
-	if strings.HasPrefix(line, "{") {
-		if matches := braceAndUrlRe.FindStringSubmatch(line); len(matches) == 3 {
+	// Reference links are written as `{id} url` or `id=... href=...`
+	if first := tokens[0]; first.Key == "id" || (first.Key == "" && !first.Quoted && braceRe.MatchString(first.Value)) {
+		if first.Key == "" {
+			tokens[0].Value = braceRe.FindStringSubmatch(first.Value)[1]
+		}
+
+		if args, ok := bindDirectiveArgs(tokens, "id", "href"); ok {
 			return ParsedDirective{
 				Type:  DirLink,
-				Args:  matches[1:],
+				Args:  args,
 				Flags: IsValid | IsReferenceLink,
 			}
 		}
+
+		return newInvalidDirective(DirLink)
+	}
+
+	if args, ok := bindDirectiveArgs(tokens, "title", "href"); ok {
+		return ParsedDirective{
+			Type:  DirLink,
+			Args:  args,
+			Flags: IsValid | IsNamedLink,
+		}
 	}
 
 	return newInvalidDirective(DirLink)
 }
 
+// parseRegexDirective parses `/pattern/flags [examples...] [description="..."]`,
+// where the optional flags (i, m, s, U) are turned into an inline `(?flags)`
+// group at the start of the pattern. Examples are kept as written, quotes
+// included; unquoteDirectiveArgs returns their values.
 func parseRegexDirective(line string) ParsedDirective {
-	if !strings.HasPrefix(line, "/") {
+	pattern, remaining, ok := cutRegexLiteral(line)
+	if !ok {
+		return newInvalidDirective(DirRegex)
+	}
+
+	if _, err := regexp.Compile(pattern); err != nil {
+		return newInvalidDirective(DirRegex)
+	}
+
+	tokens, err := tokenizeDirective(remaining)
+	if err != nil {
 		return newInvalidDirective(DirRegex)
 	}
 
+	directive := ParsedDirective{
+		Type:  DirRegex,
+		Args:  []string{pattern},
+		Flags: IsValid,
+	}
+
+	for _, token := range tokens {
+		if token.Key == "description" {
+			directive.NamedArgs = map[string]string{"description": token.Value}
+		} else {
+			directive.Args = append(directive.Args, token.Raw)
+		}
+	}
+
+	return directive
+}
+
+// unquoteDirectiveArgs returns the values of arguments kept as written, without
+// their quotes and escapes
+func unquoteDirectiveArgs(args []string) []string {
+	return unquoteArgs(args, unicode.IsSpace)
+}
+
+// enumValues returns the values of the arguments of an `@enum` directive, which
+// are kept as written, without their quotes and escapes
+func enumValues(args []string) []string {
+	return unquoteArgs(args, isEnumDelimiter)
+}
+
+func unquoteArgs(args []string, isSeparator func(rune) bool) []string {
+	values := make([]string, len(args))
+
+	for i, arg := range args {
+		values[i] = arg
+		if token, rest, ok, err := cutDirectiveTokenFunc(arg, isSeparator); ok && err == nil && rest == "" {
+			values[i] = token.Positional()
+		}
+	}
+
+	return values
+}
+
+// cutRegexLiteral splits a line starting with a `/pattern/flags` literal into the
+// pattern (with its flags inlined) and the rest of the line
+func cutRegexLiteral(line string) (pattern string, remaining string, ok bool) {
+	if !strings.HasPrefix(line, "/") {
+		return "", "", false
+	}
+
 	closingSlashIdx := -1
 	for i := 1; i < len(line); i++ {
 		if line[i] == '/' && (i == 1 || line[i-1] != '\\') {
@@ -148,22 +283,75 @@ func parseRegexDirective(line string) ParsedDirective {
 	}
 
 	if closingSlashIdx <= 0 {
-		return newInvalidDirective(DirRegex)
+		return "", "", false
 	}
 
-	pattern := line[1:closingSlashIdx]
-	if _, err := regexp.Compile(pattern); err != nil {
-		return newInvalidDirective(DirRegex)
+	pattern = line[1:closingSlashIdx]
+	flags := line[closingSlashIdx+1:]
+
+	if idx := strings.IndexFunc(flags, unicode.IsSpace); idx >= 0 {
+		flags, remaining = flags[:idx], flags[idx:]
+	}
+
+	if strings.Trim(flags, "imsU") != "" {
+		return "", "", false
+	}
+
+	if flags != "" {
+		pattern = "(?" + flags + ")" + pattern
 	}
 
-	args := []string{pattern}
-	remaining := strings.TrimSpace(line[closingSlashIdx+1:])
+	return pattern, remaining, true
+}
+
+// parseKeyDirective parses `[description] [/pattern/flags [examples...]]` into the
+// arguments `[description, pattern, examples...]`, examples being kept as written
+// like those of `@regex`. An unquoted description ends
+// at the first word starting with a slash, which must start a valid pattern.
+func parseKeyDirective(line string) ParsedDirective {
+	description := ""
+	remaining := line
+
+	if !strings.HasPrefix(line, "/") {
+		token, rest, ok, err := cutDirectiveToken(line)
+		if err != nil || !ok {
+			return newInvalidDirective(DirKey)
+		}
+
+		if (token.Quoted && token.Key == "") || token.Key == "description" {
+			description, remaining = token.Value, strings.TrimSpace(rest)
+		} else if loc := patternStartRe.FindStringIndex(line); loc != nil {
+			description, remaining = strings.TrimSpace(line[:loc[0]]), line[loc[0]+1:]
+		} else {
+			description, remaining = line, ""
+		}
+	}
+
+	args := []string{description, ""}
+
 	if remaining != "" {
-		args = append(args, splitBySpacePreserveQuotes(remaining)...)
+		pattern, rest, ok := cutRegexLiteral(remaining)
+		if !ok {
+			return newInvalidDirective(DirKey)
+		}
+
+		if _, err := regexp.Compile(pattern); err != nil {
+			return newInvalidDirective(DirKey)
+		}
+
+		tokens, err := tokenizeDirective(rest)
+		if err != nil {
+			return newInvalidDirective(DirKey)
+		}
+
+		args[1] = pattern
+		for _, token := range tokens {
+			args = append(args, token.Raw)
+		}
 	}
 
 	return ParsedDirective{
-		Type:  DirRegex,
+		Type:  DirKey,
 		Args:  args,
 		Flags: IsValid,
 	}
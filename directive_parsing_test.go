@@ -104,6 +104,51 @@ func TestParseDirective_EnumDirectiveWithSpaces(t *testing.T) {
 	}
 }
 
+func TestParseDirective_EnumDirectiveWithQuotes(t *testing.T) {
+	tests := map[string][]string{
+		`"a|b" | c`:               {`"a|b"`, "c"},
+		`'one two' | "it\"s" | x`: {`'one two'`, `"it\"s"`, "x"},
+		`a||b`:                    {"a", "", "b"},
+		``:                        {""},
+	}
+
+	for raw, args := range tests {
+		expected := ParsedDirective{
+			Type:  DirEnum,
+			Args:  args,
+			Flags: IsValid,
+		}
+
+		actual := ParseDirective("enum", raw)
+
+		if diff := deep.Equal(expected, actual); diff != nil {
+			t.Errorf("%s: expected %+v, but got %+v", raw, expected, actual)
+		}
+	}
+}
+
+func TestParseDirective_EnumDirectiveWithApostrophe(t *testing.T) {
+	expected := ParsedDirective{Type: DirEnum, Args: []string{"don't", "do"}, Flags: IsValid}
+
+	if diff := deep.Equal(expected, ParseDirective("enum", "don't | do")); diff != nil {
+		t.Errorf("Unexpected directive:\n%v", diff)
+	}
+}
+
+func TestEnumValues(t *testing.T) {
+	args := ParseDirective("enum", `"it\"s" | 'a|b' | c d | don't`).Args
+
+	if diff := deep.Equal(enumValues(args), []string{`it"s`, "a|b", "c d", "don't"}); diff != nil {
+		t.Errorf("Enum values mismatch:\n%v", diff)
+	}
+}
+
+func TestParseDirective_EnumDirectiveUnterminatedQuote(t *testing.T) {
+	if actual := ParseDirective("enum", `"a | b`); actual.Flags != IsInvalid {
+		t.Errorf("Expected an invalid directive, but got %+v", actual)
+	}
+}
+
 func TestParseDirective_RegexDirective(t *testing.T) {
 	raw := "/^[a-zA-Z0-9_-]{5}$/ abcd4 efgh_ ijkl-"
 
@@ -145,6 +190,28 @@ func TestParseDirective_RegexDirectiveWithSpaces(t *testing.T) {
 	}
 }
 
+func TestParseDirective_RegexDirectiveQuotedExamples(t *testing.T) {
+	raw := `/\w+ \w+/ "hello world" 'it\'s here' description="Two words"`
+
+	expected := ParsedDirective{
+		Type:      DirRegex,
+		Args:      []string{`\w+ \w+`, `"hello world"`, `'it\'s here'`},
+		Flags:     IsValid,
+		NamedArgs: map[string]string{"description": "Two words"},
+	}
+
+	actual := ParseDirective("regex", raw)
+
+	if diff := deep.Equal(expected, actual); diff != nil {
+		t.Errorf("Expected %+v, but got %+v", expected, actual)
+	}
+
+	// Examples are kept as written and unquoted where they are used
+	if diff := deep.Equal(unquoteDirectiveArgs(actual.Args[1:]), []string{"hello world", "it's here"}); diff != nil {
+		t.Errorf("Unquoted examples mismatch:\n%v", diff)
+	}
+}
+
 func TestParseDirective_RegexWithSlash(t *testing.T) {
 	raw := "/^https?:\\/\\// https://example.com http://test.com"
 
@@ -164,3 +231,159 @@ func TestParseDirective_RegexWithSlash(t *testing.T) {
 		t.Errorf("Expected %+v, but got %+v", expected, actual)
 	}
 }
+
+func TestParseDirective_SinceDirective(t *testing.T) {
+	expected := ParsedDirective{
+		Type:  DirSince,
+		Args:  []string{"1.2.0-rc.1"},
+		Flags: IsValid,
+	}
+
+	actual := ParseDirective("since", "1.2.0-rc.1")
+
+	if diff := deep.Equal(expected, actual); diff != nil {
+		t.Errorf("Expected %+v, but got %+v", expected, actual)
+	}
+}
+
+func TestParseDirective_SinceDirectiveInvalidVersion(t *testing.T) {
+	expected := ParsedDirective{
+		Type:  DirSince,
+		Args:  []string{},
+		Flags: IsInvalid,
+	}
+
+	actual := ParseDirective("since", "v1.O")
+
+	if diff := deep.Equal(expected, actual); diff != nil {
+		t.Errorf("Expected %+v, but got %+v", expected, actual)
+	}
+}
+
+func TestParseDirective_ExampleDirectiveWithEscapedQuote(t *testing.T) {
+	raw := `"The \"Basic\" Example" #basic`
+
+	expected := ParsedDirective{
+		Type:  DirExample,
+		Args:  []string{"The \"Basic\" Example", "#basic"},
+		Flags: IsValid,
+	}
+
+	actual := ParseDirective("example", raw)
+
+	if diff := deep.Equal(expected, actual); diff != nil {
+		t.Errorf("Expected %+v, but got %+v", expected, actual)
+	}
+}
+
+func TestParseDirective_ExampleDirectiveWithNamedArgs(t *testing.T) {
+	expected := ParsedDirective{
+		Type:  DirExample,
+		Args:  []string{"Basic Usage", "#basic"},
+		Flags: IsValid,
+	}
+
+	for _, raw := range []string{`title="Basic Usage" href=#basic`, `href=#basic title='Basic Usage'`, `'Basic Usage' href=#basic`} {
+		actual := ParseDirective("example", raw)
+
+		if diff := deep.Equal(expected, actual); diff != nil {
+			t.Errorf("%s: expected %+v, but got %+v", raw, expected, actual)
+		}
+	}
+}
+
+func TestParseDirective_ExampleDirectiveInvalid(t *testing.T) {
+	for _, raw := range []string{`"Unterminated #basic`, `"Title only"`, `"Title" #basic extra`, `title=a name=b`} {
+		if actual := ParseDirective("example", raw); actual.Flags != IsInvalid {
+			t.Errorf("%s: expected an invalid directive, but got %+v", raw, actual)
+		}
+	}
+}
+
+func TestParseDirective_NamedReferenceLinkDirective(t *testing.T) {
+	expected := ParsedDirective{
+		Type:  DirLink,
+		Args:  []string{"efs-access-point", "https://docs.aws.amazon.com/efs/latest/ug/efs-access-points.html"},
+		Flags: IsValid | IsReferenceLink,
+	}
+
+	actual := ParseDirective("link", "id=efs-access-point href=https://docs.aws.amazon.com/efs/latest/ug/efs-access-points.html")
+
+	if diff := deep.Equal(expected, actual); diff != nil {
+		t.Errorf("Expected %+v, but got %+v", expected, actual)
+	}
+}
+
+func TestParseDirective_RegexDirectiveWithFlagsAndDescription(t *testing.T) {
+	raw := `/^[a-z]+$/i ABC def description="Letters only, case insensitive"`
+
+	expected := ParsedDirective{
+		Type: DirRegex,
+		Args: []string{
+			"(?i)^[a-z]+$",
+			"ABC",
+			"def",
+		},
+		Flags:     IsValid,
+		NamedArgs: map[string]string{"description": "Letters only, case insensitive"},
+	}
+
+	actual := ParseDirective("regex", raw)
+
+	if diff := deep.Equal(expected, actual); diff != nil {
+		t.Errorf("Expected %+v, but got %+v", expected, actual)
+	}
+}
+
+func TestParseDirective_RegexDirectiveWithUnknownFlag(t *testing.T) {
+	if actual := ParseDirective("regex", "/^[a-z]+$/g abc"); actual.Flags != IsInvalid {
+		t.Errorf("Expected an invalid directive, but got %+v", actual)
+	}
+}
+
+func TestParseDirective_KeyDirective(t *testing.T) {
+	tests := map[string][]string{
+		`The name of the access point`:                     {"The name of the access point", ""},
+		`"The name of the access point" /^[a-z-]+$/ my-ap`: {"The name of the access point", "^[a-z-]+$", "my-ap"},
+		`description='AZ' /^[a-z]{2}-[a-z]+-\d[a-z]$/i`:    {"AZ", "(?i)^[a-z]{2}-[a-z]+-\\d[a-z]$"},
+		`/^\d+$/ "1" "22"`:                                 {"", "^\\d+$", `"1"`, `"22"`},
+		`The name /^[a-z]+$/ web`:                          {"The name", "^[a-z]+$", "web"},
+	}
+
+	for raw, args := range tests {
+		expected := ParsedDirective{
+			Type:  DirKey,
+			Args:  args,
+			Flags: IsValid,
+		}
+
+		actual := ParseDirective("key", raw)
+
+		if diff := deep.Equal(expected, actual); diff != nil {
+			t.Errorf("%s: expected %+v, but got %+v", raw, expected, actual)
+		}
+	}
+}
+
+func TestParseDirective_KeyDirectiveInvalid(t *testing.T) {
+	for _, raw := range []string{``, `"Name" not-a-pattern`, `"Name" /[/`, `The name /[a-z/`} {
+		if actual := ParseDirective("key", raw); actual.Flags != IsInvalid {
+			t.Errorf("%s: expected an invalid directive, but got %+v", raw, actual)
+		}
+	}
+}
+
+func TestParseDirective_LintIgnore(t *testing.T) {
+	actual := ParseDirective("lint-ignore", "undocumented, unknown-directive")
+	expected := ParsedDirective{Type: DirLintIgnore, Args: []string{"undocumented", "unknown-directive"}, Flags: IsValid}
+
+	if diff := deep.Equal(actual, expected); diff != nil {
+		t.Errorf("Directive mismatch:\n%v", diff)
+	}
+
+	for _, line := range []string{"", "no-such-rule"} {
+		if parsed := ParseDirective("lint-ignore", line); parsed.Flags&IsInvalid == 0 {
+			t.Errorf("Expected %q to be invalid, got %+v", line, parsed)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package tfdocextras
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+var namedArgKeyRe = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+
+// directiveToken is a single argument of a directive. Named arguments (i.e.
+// `key=value`) have a non-empty Key. Raw is the argument as written, quotes and
+// escapes included.
+type directiveToken struct {
+	Key    string
+	Value  string
+	Raw    string
+	Quoted bool
+}
+
+// Positional returns the token as it would be read without named argument
+// support (e.g. `key=value`)
+func (t directiveToken) Positional() string {
+	if t.Key != "" {
+		return t.Key + "=" + t.Value
+	}
+
+	return t.Value
+}
+
+// tokenizeDirective splits the content of a directive into whitespace separated
+// tokens. Double or single quotes group text containing whitespace into a single
+// token, and a backslash escapes a quote, a backslash, or whitespace. Any other
+// backslash is kept as-is so regex examples such as `"\d+"` survive untouched.
+// A token starting with `key=` is a named argument whose value may be quoted.
+func tokenizeDirective(line string) ([]directiveToken, error) {
+	var tokens []directiveToken
+
+	for {
+		token, rest, ok, err := cutDirectiveToken(line)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return tokens, nil
+		}
+
+		tokens = append(tokens, token)
+		line = rest
+	}
+}
+
+// cutDirectiveToken reads the first token of line and returns it along with the
+// unread remainder of the line. ok is false when line only contains whitespace.
+func cutDirectiveToken(line string) (token directiveToken, rest string, ok bool, err error) {
+	return cutDirectiveTokenFunc(line, unicode.IsSpace)
+}
+
+// cutDirectiveTokenFunc reads the first token of line up to an unquoted rune
+// satisfying isSeparator, which starts the returned remainder of the line.
+// Whitespace around the token is not part of it.
+func cutDirectiveTokenFunc(line string, isSeparator func(rune) bool) (token directiveToken, rest string, ok bool, err error) {
+	runes := []rune(strings.TrimLeftFunc(line, unicode.IsSpace))
+	if len(runes) == 0 {
+		return directiveToken{}, "", false, nil
+	}
+
+	var value strings.Builder
+	var quote rune
+	i := 0
+
+	// Lengths of the value and of the raw text up to their last rune that is
+	// not unquoted whitespace, which only separators other than whitespace leave
+	valueEnd, rawEnd := 0, 0
+
+	// Quotes only open at the start of the value, so that apostrophes within
+	// words (e.g. `don't`) are kept as-is
+	valueStart := 0
+
+	for ; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case r == '\\' && i+1 < len(runes) && isEscapable(runes[i+1], quote):
+			value.WriteRune(runes[i+1])
+			i++
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				value.WriteRune(r)
+			}
+		case isSeparator(r):
+			token.Value = value.String()[:valueEnd]
+			token.Raw = string(runes[:rawEnd])
+
+			return token, string(runes[i:]), true, nil
+		case unicode.IsSpace(r):
+			value.WriteRune(r)
+			continue
+		case (r == '"' || r == '\'') && i == valueStart:
+			quote = r
+			token.Quoted = true
+
+		// The first unquoted `=` separates the name of a named argument from its value
+		case r == '=' && token.Key == "" && !token.Quoted && namedArgKeyRe.MatchString(value.String()):
+			token.Key = value.String()
+			value.Reset()
+			valueStart = i + 1
+		default:
+			value.WriteRune(r)
+		}
+
+		valueEnd, rawEnd = value.Len(), i+1
+	}
+
+	if quote != 0 {
+		return directiveToken{}, "", false, fmt.Errorf("unterminated %c quote in %q", quote, line)
+	}
+
+	token.Value = value.String()[:valueEnd]
+	token.Raw = string(runes[:rawEnd])
+
+	return token, "", true, nil
+}
+
+func isEscapable(r rune, quote rune) bool {
+	if r == '\\' || r == '"' || r == '\'' {
+		return true
+	}
+
+	return quote == 0 && unicode.IsSpace(r)
+}
+
+// bindDirectiveArgs assigns tokens to the given parameter names. Named arguments
+// are matched by name and the remaining positional tokens fill the unassigned
+// parameters in order. It fails when a token cannot be assigned or a parameter
+// is left empty.
+func bindDirectiveArgs(tokens []directiveToken, params ...string) ([]string, bool) {
+	args := make([]string, len(params))
+	assigned := make([]bool, len(params))
+	var positional []string
+
+	for _, token := range tokens {
+		if token.Key == "" {
+			positional = append(positional, token.Value)
+			continue
+		}
+
+		idx := slices.Index(params, token.Key)
+		if idx < 0 || assigned[idx] {
+			return nil, false
+		}
+
+		args[idx] = token.Value
+		assigned[idx] = true
+	}
+
+	for i := range params {
+		if assigned[i] {
+			continue
+		}
+
+		if len(positional) == 0 {
+			return nil, false
+		}
+
+		args[i] = positional[0]
+		positional = positional[1:]
+	}
+
+	return args, len(positional) == 0
+}
@@ -0,0 +1,77 @@
+package tfdocextras
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestTokenizeDirective_QuotesAndEscapes(t *testing.T) {
+	raw := `plain "double quoted" 'single quoted' "escaped \"quote\"" 'it\'s' back\\slash \d+ "\w+"`
+
+	expected := []directiveToken{
+		{Value: "plain", Raw: "plain"},
+		{Value: "double quoted", Raw: `"double quoted"`, Quoted: true},
+		{Value: "single quoted", Raw: `'single quoted'`, Quoted: true},
+		{Value: `escaped "quote"`, Raw: `"escaped \"quote\""`, Quoted: true},
+		{Value: "it's", Raw: `'it\'s'`, Quoted: true},
+		{Value: `back\slash`, Raw: `back\\slash`},
+		{Value: `\d+`, Raw: `\d+`},
+		{Value: `\w+`, Raw: `"\w+"`, Quoted: true},
+	}
+
+	actual, err := tokenizeDirective(raw)
+	if err != nil {
+		t.Fatalf("tokenizeDirective failed: %v", err)
+	}
+
+	if diff := deep.Equal(expected, actual); diff != nil {
+		t.Errorf("Tokens mismatch:\n%v", diff)
+	}
+}
+
+func TestTokenizeDirective_NamedArguments(t *testing.T) {
+	raw := `title="Basic Usage" href=#basic https://example.com/?a=b "quoted=value"`
+
+	expected := []directiveToken{
+		{Key: "title", Value: "Basic Usage", Raw: `title="Basic Usage"`, Quoted: true},
+		{Key: "href", Value: "#basic", Raw: "href=#basic"},
+		{Value: "https://example.com/?a=b", Raw: "https://example.com/?a=b"},
+		{Value: "quoted=value", Raw: `"quoted=value"`, Quoted: true},
+	}
+
+	actual, err := tokenizeDirective(raw)
+	if err != nil {
+		t.Fatalf("tokenizeDirective failed: %v", err)
+	}
+
+	if diff := deep.Equal(expected, actual); diff != nil {
+		t.Errorf("Tokens mismatch:\n%v", diff)
+	}
+}
+
+func TestTokenizeDirective_ApostropheWithinWord(t *testing.T) {
+	raw := `don't "it's quoted" title=Rock'n'roll href='#it"s'`
+
+	expected := []directiveToken{
+		{Value: "don't", Raw: "don't"},
+		{Value: "it's quoted", Raw: `"it's quoted"`, Quoted: true},
+		{Key: "title", Value: "Rock'n'roll", Raw: "title=Rock'n'roll"},
+		{Key: "href", Value: `#it"s`, Raw: `href='#it"s'`, Quoted: true},
+	}
+
+	actual, err := tokenizeDirective(raw)
+	if err != nil {
+		t.Fatalf("tokenizeDirective failed: %v", err)
+	}
+
+	if diff := deep.Equal(expected, actual); diff != nil {
+		t.Errorf("Tokens mismatch:\n%v", diff)
+	}
+}
+
+func TestTokenizeDirective_UnterminatedQuote(t *testing.T) {
+	if _, err := tokenizeDirective(`"no closing quote`); err == nil {
+		t.Error("Expected an error for an unterminated quote")
+	}
+}
@@ -1,6 +1,7 @@
 package tfdocextras
 
 import (
+	"slices"
 	"strings"
 
 	"golang.org/x/text/cases"
@@ -12,6 +13,7 @@ type DocDirective struct {
 	Name       string          `json:"name"`
 	Parsed     ParsedDirective `json:"parsed"`
 	RawContent string          `json:"rawContent"`
+	Inherited  bool            `json:"inherited,omitempty"`
 }
 
 // FieldDocBlock contains parsed documentation for a field
@@ -20,6 +22,13 @@ type FieldDocBlock struct {
 	Directives []DocDirective `json:"directives"`
 }
 
+// MapKeyDoc documents the keys of a map-typed field via the `@key` directive
+type MapKeyDoc struct {
+	Description string   `json:"description,omitempty"`
+	Pattern     string   `json:"pattern,omitempty"`
+	Examples    []string `json:"examples,omitempty"`
+}
+
 // ObjectField represents a field within an object structure
 type ObjectField struct {
 	Name           string        `json:"name"`
@@ -28,6 +37,7 @@ type ObjectField struct {
 	Optional       bool          `json:"optional"`
 	DefaultValue   *string       `json:"defaultValue,omitempty"`
 	NestedDataType *string       `json:"nestedDataType,omitempty"`
+	MapKey         *MapKeyDoc    `json:"mapKey,omitempty"`
 	Fields         []ObjectField `json:"fields,omitempty"`
 }
 
@@ -38,6 +48,33 @@ type ObjectGroup struct {
 	ParentDataType *string `json:"parentDataType,omitempty"`
 }
 
+// child returns the nested field called name, or nil when f is nil or has no
+// such field
+func (f *ObjectField) child(name string) *ObjectField {
+	if f == nil {
+		return nil
+	}
+
+	for i := range f.Fields {
+		if f.Fields[i].Name == name {
+			return &f.Fields[i]
+		}
+	}
+
+	return nil
+}
+
+// hasDirective reports whether f declares a valid directive of type dirType
+func (f *ObjectField) hasDirective(dirType DirectiveType) bool {
+	if f == nil {
+		return false
+	}
+
+	return slices.ContainsFunc(f.Documentation.Directives, func(attr DocDirective) bool {
+		return attr.Parsed.Type == dirType && (attr.Parsed.Flags&IsValid) != 0
+	})
+}
+
 func extractObjectFromArg(arg *astDataType) []ObjectField {
 	if isObjectType(*arg) {
 		if arg.Func.Args[0].Object != nil {
@@ -73,6 +110,11 @@ func isOptionalType(data astDataType) bool {
 	return data.Func != nil && data.Func.Name == "optional"
 }
 
+// isMapTypeStr reports whether a flattened data type string is a map
+func isMapTypeStr(typeStr string) bool {
+	return strings.HasPrefix(typeStr, "map(")
+}
+
 func isCollectionType(data astDataType) bool {
 	return data.Func != nil && (data.Func.Name == "map" || data.Func.Name == "list")
 }
@@ -282,6 +324,24 @@ func parseDocBlock(block astDocBlock) FieldDocBlock {
 	return doc
 }
 
+// parseMapKey returns the documentation of a map's keys from its first valid
+// `@key` directive, if any
+func parseMapKey(directives []DocDirective) *MapKeyDoc {
+	for _, directive := range directives {
+		if directive.Parsed.Type != DirKey || (directive.Parsed.Flags&IsValid) == 0 {
+			continue
+		}
+
+		return &MapKeyDoc{
+			Description: directive.Parsed.Args[0],
+			Pattern:     directive.Parsed.Args[1],
+			Examples:    directive.Parsed.Args[2:],
+		}
+	}
+
+	return nil
+}
+
 func parseObjectBlock(obj astObject) []ObjectField {
 	var fields []ObjectField
 
@@ -293,6 +353,11 @@ func parseObjectBlock(obj astObject) []ObjectField {
 		}
 
 		parseFieldType(&field, pair.Value)
+
+		if isMapTypeStr(field.DataTypeStr) {
+			field.MapKey = parseMapKey(field.Documentation.Directives)
+		}
+
 		fields = append(fields, field)
 	}
 
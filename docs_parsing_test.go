@@ -151,7 +151,7 @@ func TestParseDocBlock_DirectiveWithoutContent(t *testing.T) {
 	// Check directives without content
 	expectedDirectives := []DocDirective{
 		{Name: "deprecated", RawContent: "", Parsed: ParsedDirective{Type: DirDeprecated, Args: []string{""}, Flags: IsValid}},
-		{Name: "internal", RawContent: "", Parsed: ParsedDirective{Type: DirUnsupported, Args: []string{}, Flags: IsInvalid}},
+		{Name: "internal", RawContent: "", Parsed: ParsedDirective{Type: DirInternal, Args: []string{""}, Flags: IsValid}},
 		{Name: "final", RawContent: "", Parsed: ParsedDirective{Type: DirUnsupported, Args: []string{}, Flags: IsInvalid}},
 	}
 
@@ -1,6 +1,9 @@
 package tfdocextras
 
 import (
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/terraform-docs/terraform-docs/terraform"
@@ -8,18 +11,36 @@ import (
 	"golang.org/x/text/language"
 )
 
+// referenceLinkRe matches the full (`[text][id]`) and collapsed (`[id][]`)
+// reference links of markdown
+var referenceLinkRe = regexp.MustCompile(`\[([^\]]+)\]\[([^\]]*)\]`)
+
 type TableRowAttribute struct {
-	Name    string `json:"name,omitempty"`
-	Content string `json:"content,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Content   string `json:"content,omitempty"`
+	Inherited bool   `json:"inherited,omitempty"`
+}
+
+// RegexConstraint is a pattern documented with the `@regex` directive, or
+// inferred from a validation block
+type RegexConstraint struct {
+	Pattern     string   `json:"pattern"`
+	Description string   `json:"description,omitempty"`
+	Examples    []string `json:"examples,omitempty"`
+	Inferred    bool     `json:"inferred,omitempty"`
 }
 
 type RowMetadata struct {
-	Attributes    []TableRowAttribute `json:"attributes,omitempty"`
-	Enumerations  []string            `json:"enumerations,omitempty"`
-	Examples      []TableRowAttribute `json:"examples,omitempty"`
-	Links         []TableRowAttribute `json:"links,omitempty"`
-	RegexPattern  string              `json:"regex_pattern,omitempty"`
-	RegexExamples []string            `json:"regex_examples,omitempty"`
+	Attributes       []TableRowAttribute `json:"attributes,omitempty"`
+	Enumerations     []string            `json:"enumerations,omitempty"`
+	Examples         []TableRowAttribute `json:"examples,omitempty"`
+	Links            []TableRowAttribute `json:"links,omitempty"`
+	RegexConstraints []RegexConstraint   `json:"regex_constraints,omitempty"`
+	MapKey           *MapKeyDoc          `json:"map_key,omitempty"`
+
+	// EnumerationsInferred is set when the enumerations come from a validation
+	// block rather than an `@enum` directive
+	EnumerationsInferred bool `json:"enumerations_inferred,omitempty"`
 }
 
 type TableRow struct {
@@ -28,7 +49,11 @@ type TableRow struct {
 	Name         string  `json:"name,omitempty"`
 	DefaultValue string  `json:"default_value,omitempty"`
 	Description  string  `json:"description,omitempty"`
+	NewIn        string  `json:"new_in,omitempty"`
 	RowMetadata
+
+	// Validations are the validation blocks of a variable
+	Validations []VariableValidation `json:"validations,omitempty"`
 }
 
 func (r *TableRow) GetAnchor() string {
@@ -67,23 +92,178 @@ func (d *TableData) GetMetadata() *RowMetadata {
 	return &d.RowMetadata
 }
 
+// WhatsNewEntry is an input or nested field introduced in the current version
+type WhatsNewEntry struct {
+	Path        string `json:"path"`
+	Anchor      string `json:"anchor,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// ManifestVersion is the version of the InputsManifest data contract exposed to
+// templates and JSON consumers. It is incremented whenever a field is renamed or
+// removed, or its meaning changes; adding fields does not change it.
+const ManifestVersion = 1
+
 type InputsManifest struct {
+	Version        int                  `json:"version"`
 	RequiredInputs TableData            `json:"required_inputs,omitempty"`
 	OptionalInputs TableData            `json:"optional_inputs,omitempty"`
 	NestedInputs   map[string]TableData `json:"nested_inputs,omitempty"`
+	Outputs        TableData            `json:"outputs,omitempty"`
 	ReferenceLinks map[string]string    `json:"reference_links,omitempty"`
+	CurrentVersion string               `json:"current_version,omitempty"`
+	WhatsNew       []WhatsNewEntry      `json:"whats_new,omitempty"`
+	Diagnostics    []Diagnostic         `json:"diagnostics,omitempty"`
+
+	// referenceUses are the reference links used in descriptions, checked by
+	// DiagnoseReferenceLinks once every `@link {id}` has been recorded
+	referenceUses []referenceUse
+}
+
+// referenceUse is a reference link (e.g. `[text][id]`) used in a description
+type referenceUse struct {
+	id  string
+	loc diagnosticLocation
+}
+
+// ManifestOptions controls how a module's inputs are turned into an InputsManifest
+type ManifestOptions struct {
+	// InheritedDirectives lists the directive names (e.g. "since") that nested
+	// fields inherit from their parent object unless they declare their own
+	InheritedDirectives []string
+
+	// CurrentVersion is the version being released; inputs and nested fields
+	// whose `@since` matches it are flagged as new
+	CurrentVersion string
+
+	// HiddenDirectives lists the directive names that are left out of the
+	// manifest; they are still parsed and diagnosed
+	HiddenDirectives []string
+
+	// InferredConstraints are the constraints recognized in the validation
+	// blocks of the module; they document the fields without `@enum` or
+	// `@regex` directives of their own
+	InferredConstraints []InferredConstraint
+
+	// Validations are the validation blocks of the module's variables, attached
+	// to the rows of the variables they belong to
+	Validations []VariableValidation
+}
+
+// visibleDirectives returns the directives that are not hidden by the options
+func (o *ManifestOptions) visibleDirectives(directives []DocDirective) []DocDirective {
+	if len(o.HiddenDirectives) == 0 {
+		return directives
+	}
+
+	visible := make([]DocDirective, 0, len(directives))
+	for _, directive := range directives {
+		if !slices.Contains(o.HiddenDirectives, directive.Name) {
+			visible = append(visible, directive)
+		}
+	}
+
+	return visible
+}
+
+// DefaultManifestOptions returns the options used by ParseModuleInputsIntoManifest
+func DefaultManifestOptions() ManifestOptions {
+	return ManifestOptions{
+		InheritedDirectives: []string{"since", "deprecated", "stability", "internal"},
+	}
+}
+
+// directiveScope carries the state a nested field receives from its parents
+type directiveScope struct {
+	path       string
+	position   terraform.Position
+	inherited  []DocDirective
+	suppressed []string
+	options    *ManifestOptions
+}
+
+// diagnosticLocation identifies the field a Diagnostic is reported for, along
+// with the rules suppressed on it
+type diagnosticLocation struct {
+	path       string
+	position   terraform.Position
+	suppressed []string
+}
+
+func (s directiveScope) locate(name string) diagnosticLocation {
+	path := name
+
+	if s.path != "" {
+		path = s.path + "." + name
+	}
+
+	return diagnosticLocation{
+		path:       path,
+		position:   s.position,
+		suppressed: s.suppressed,
+	}
+}
+
+// suppress returns the location with the rules of the `@lint-ignore` directives
+// among directives suppressed as well
+func (l diagnosticLocation) suppress(directives []DocDirective) diagnosticLocation {
+	for _, attr := range directives {
+		if attr.Parsed.Type == DirLintIgnore && (attr.Parsed.Flags&IsValid) != 0 {
+			l.suppressed = append(slices.Clip(l.suppressed), attr.Parsed.Args...)
+		}
+	}
+
+	return l
+}
+
+func (m *InputsManifest) addDiagnostic(loc diagnosticLocation, severity DiagnosticSeverity, code, message string) {
+	if slices.Contains(loc.suppressed, code) || slices.Contains(loc.suppressed, "all") {
+		return
+	}
+
+	m.Diagnostics = append(m.Diagnostics, Diagnostic{
+		Severity: severity,
+		Code:     code,
+		Path:     loc.path,
+		Message:  message,
+		Filename: loc.position.Filename,
+		Line:     loc.position.Line,
+	})
+}
+
+// inheritDirectives returns the effective directives of a field: its own
+// directives plus every inheritable directive of its parent that the field does
+// not declare itself.
+func inheritDirectives(own []DocDirective, scope directiveScope) []DocDirective {
+	effective := slices.Clone(own)
+
+	for _, parent := range scope.inherited {
+		if !slices.Contains(scope.options.InheritedDirectives, parent.Name) {
+			continue
+		}
+
+		declared := slices.ContainsFunc(own, func(d DocDirective) bool {
+			return d.Name == parent.Name
+		})
+
+		if !declared {
+			parent.Inherited = true
+			effective = append(effective, parent)
+		}
+	}
+
+	return effective
 }
 
 func newTableData() TableData {
 	return TableData{
 		Description: "",
 		RowMetadata: RowMetadata{
-			Attributes:    []TableRowAttribute{},
-			Enumerations:  []string{},
-			Examples:      []TableRowAttribute{},
-			Links:         []TableRowAttribute{},
-			RegexPattern:  "",
-			RegexExamples: []string{},
+			Attributes:       []TableRowAttribute{},
+			Enumerations:     []string{},
+			Examples:         []TableRowAttribute{},
+			Links:            []TableRowAttribute{},
+			RegexConstraints: []RegexConstraint{},
 		},
 		Rows: []TableRow{},
 	}
@@ -96,20 +276,21 @@ func newTableRow(typeStr, name, defaultValue, description string) TableRow {
 		DefaultValue: defaultValue,
 		Description:  description,
 		RowMetadata: RowMetadata{
-			Attributes:    []TableRowAttribute{},
-			Enumerations:  []string{},
-			Examples:      []TableRowAttribute{},
-			Links:         []TableRowAttribute{},
-			RegexPattern:  "",
-			RegexExamples: []string{},
+			Attributes:       []TableRowAttribute{},
+			Enumerations:     []string{},
+			Examples:         []TableRowAttribute{},
+			Links:            []TableRowAttribute{},
+			RegexConstraints: []RegexConstraint{},
 		},
 	}
 }
 
 func newTemplateData() *InputsManifest {
 	return &InputsManifest{
+		Version:        ManifestVersion,
 		RequiredInputs: newTableData(),
 		OptionalInputs: newTableData(),
+		Outputs:        newTableData(),
 		NestedInputs:   make(map[string]TableData),
 		ReferenceLinks: make(map[string]string),
 	}
@@ -152,19 +333,251 @@ func processDirectives(directives []DocDirective, manifest *InputsManifest, data
 			}
 		case DirRegex:
 			if len(attr.Parsed.Args) >= 1 {
-				metadata.RegexPattern = attr.Parsed.Args[0]
-				metadata.RegexExamples = append(metadata.RegexExamples, attr.Parsed.Args[1:]...)
+				metadata.RegexConstraints = append(metadata.RegexConstraints, RegexConstraint{
+					Pattern:     attr.Parsed.Args[0],
+					Description: attr.Parsed.NamedArgs["description"],
+					Examples:    attr.Parsed.Args[1:],
+				})
 			}
+		case DirKey:
+			// Map keys depend on the field's type and are assigned by recordMapKey
+		case DirLintIgnore:
+			// Suppressions only affect diagnostics
 		default:
 			caser := cases.Title(language.English)
 			metadata.Attributes = append(metadata.Attributes, TableRowAttribute{
-				Name:    caser.String(attr.Name),
-				Content: attr.RawContent,
+				Name:      caser.String(attr.Name),
+				Content:   attr.RawContent,
+				Inherited: attr.Inherited,
+			})
+		}
+	}
+}
+
+// mergeInferredConstraints documents the field at path with the inferred
+// constraints that its directives do not already cover: enumerations when it has
+// no `@enum`, and patterns it has no `@regex` for
+func mergeInferredConstraints(constraints []InferredConstraint, path string, metadata *RowMetadata) {
+	documentedEnum := len(metadata.Enumerations) > 0
+
+	for _, constraint := range constraints {
+		if constraint.Path != path {
+			continue
+		}
+
+		if len(constraint.Enumerations) > 0 && !documentedEnum && !metadata.EnumerationsInferred {
+			metadata.Enumerations = append(metadata.Enumerations, constraint.Enumerations...)
+			metadata.EnumerationsInferred = true
+		}
+
+		documentedPattern := slices.ContainsFunc(metadata.RegexConstraints, func(regex RegexConstraint) bool {
+			return regex.Pattern == constraint.Pattern
+		})
+
+		if constraint.Pattern != "" && !documentedPattern {
+			metadata.RegexConstraints = append(metadata.RegexConstraints, RegexConstraint{
+				Pattern:  constraint.Pattern,
+				Examples: []string{},
+				Inferred: true,
+			})
+		}
+	}
+}
+
+// singleValueDirectives are the directives a field may declare only once
+var singleValueDirectives = []DirectiveType{DirSince, DirDeprecated, DirStability, DirInternal, DirKey}
+
+// diagnoseDirectives records a diagnostic for every directive a field declares
+// that is unknown, repeated, could not be parsed, or whose examples contradict it
+func diagnoseDirectives(directives []DocDirective, manifest *InputsManifest, loc diagnosticLocation) {
+	var declared []string
+
+	for _, attr := range directives {
+		// Inherited directives were already reported on the field declaring them
+		if attr.Inherited {
+			continue
+		}
+
+		if attr.Parsed.Type == DirUnsupported {
+			manifest.addDiagnostic(loc, SeverityWarning, DiagUnknownDirective, "@"+attr.Name+" is not a supported directive")
+			continue
+		}
+
+		if slices.Contains(singleValueDirectives, attr.Parsed.Type) {
+			if slices.Contains(declared, attr.Name) {
+				manifest.addDiagnostic(loc, SeverityWarning, DiagDuplicateDirective, "@"+attr.Name+" is declared more than once")
+			}
+
+			declared = append(declared, attr.Name)
+		}
+
+		if (attr.Parsed.Flags & IsInvalid) != 0 {
+			manifest.addDiagnostic(loc, SeverityError, DiagInvalidDirective, invalidDirectiveMessage(attr))
+			continue
+		}
+
+		switch attr.Parsed.Type {
+		case DirRegex:
+			diagnoseRegexExamples(attr, attr.Parsed.Args[0], attr.Parsed.Args[1:], manifest, loc)
+		case DirKey:
+			if attr.Parsed.Args[1] != "" {
+				diagnoseRegexExamples(attr, attr.Parsed.Args[1], attr.Parsed.Args[2:], manifest, loc)
+			}
+		}
+	}
+}
+
+func diagnoseRegexExamples(attr DocDirective, pattern string, examples []string, manifest *InputsManifest, loc diagnosticLocation) {
+	// The pattern was already compiled successfully while parsing the directive
+	re := regexp.MustCompile(pattern)
+
+	for _, example := range unquoteDirectiveArgs(examples) {
+		if !re.MatchString(example) {
+			manifest.addDiagnostic(loc, SeverityError, DiagRegexExampleMismatch,
+				"@"+attr.Name+" example \""+example+"\" does not match /"+pattern+"/")
+		}
+	}
+}
+
+// diagnoseRow records the problems of a documented row: a missing description,
+// a default value that is not one of its `@enum` values, and the reference
+// links its description uses
+func diagnoseRow(kind string, row *TableRow, manifest *InputsManifest, loc diagnosticLocation) {
+	if strings.TrimSpace(row.Description) == "" {
+		manifest.addDiagnostic(loc, SeverityWarning, DiagUndocumented, kind+" has no description")
+	}
+
+	if value, ok := scalarDefault(row.DefaultValue); ok && len(row.Enumerations) > 0 {
+		// Inferred values are not written as directive arguments
+		choices := row.Enumerations
+		if !row.EnumerationsInferred {
+			choices = enumValues(choices)
+		}
+
+		allowed := slices.Contains(choices, value)
+
+		if !allowed {
+			source := "@enum"
+			if row.EnumerationsInferred {
+				source = "validation"
+			}
+
+			manifest.addDiagnostic(loc, SeverityError, DiagEnumDefaultMismatch,
+				"default value \""+value+"\" is not one of the "+source+" values "+strings.Join(row.Enumerations, " | "))
+		}
+	}
+
+	recordReferenceUses(row.Description, manifest, loc)
+}
+
+// scalarDefault returns the value of a string, number, or boolean default value
+// as written in an `@enum` directive
+func scalarDefault(defaultValue string) (string, bool) {
+	if defaultValue == "" || defaultValue == "null" || strings.ContainsAny(defaultValue[:1], "[{") {
+		return "", false
+	}
+
+	if unquoted, err := strconv.Unquote(defaultValue); err == nil {
+		return unquoted, true
+	}
+
+	return defaultValue, true
+}
+
+// recordReferenceUses records the reference links description uses, to be
+// checked by DiagnoseReferenceLinks
+func recordReferenceUses(description string, manifest *InputsManifest, loc diagnosticLocation) {
+	for _, match := range referenceLinkRe.FindAllStringSubmatch(description, -1) {
+		// Collapsed reference links (`[id][]`) use their text as id
+		id := match[2]
+		if id == "" {
+			id = match[1]
+		}
+
+		manifest.referenceUses = append(manifest.referenceUses, referenceUse{id: id, loc: loc})
+	}
+}
+
+// DiagnoseReferenceLinks reports the reference links used in the descriptions
+// of inputs and outputs that no `@link {id}` directive of either defines.
+// Reference ids are case-insensitive. Call it once, after both
+// ParseModuleInputsIntoManifest and ParseModuleOutputsIntoManifest, since a
+// link may be used by an input and defined by an output.
+func (m *InputsManifest) DiagnoseReferenceLinks() {
+	for _, use := range m.referenceUses {
+		defined := false
+		for id := range m.ReferenceLinks {
+			defined = defined || strings.EqualFold(id, use.id)
+		}
+
+		if !defined {
+			m.addDiagnostic(use.loc, SeverityError, DiagUndefinedReferenceLink,
+				"reference link ["+use.id+"] is not defined by any @link {"+use.id+"} directive")
+		}
+	}
+
+	m.referenceUses = nil
+}
+
+// recordMapKey attaches the `@key` documentation of a map-typed field to its row
+// and reports `@key` directives used on any other type
+func recordMapKey(mapKey *MapKeyDoc, directives []DocDirective, manifest *InputsManifest, loc diagnosticLocation, row *TableRow) {
+	if mapKey != nil {
+		row.MapKey = mapKey
+		return
+	}
+
+	for _, attr := range directives {
+		if attr.Parsed.Type == DirKey && (attr.Parsed.Flags&IsValid) != 0 {
+			manifest.addDiagnostic(loc, SeverityWarning, DiagMisplacedDirective, "@key is only supported on map-typed fields")
+			return
+		}
+	}
+}
+
+func invalidDirectiveMessage(attr DocDirective) string {
+	switch attr.Parsed.Type {
+	case DirSince:
+		return "@since \"" + attr.RawContent + "\" is not a valid semantic version (e.g. 1.2.0)"
+	case DirLintIgnore:
+		return "@lint-ignore expects the codes of lint rules or \"all\", got \"" + attr.RawContent + "\""
+	}
+
+	return "@" + attr.Name + " directive could not be parsed: \"" + attr.RawContent + "\""
+}
+
+// recordWhatsNew flags a row as new when it declares a `@since` matching the
+// current version. Inherited versions are not flagged, as the parent is listed.
+func recordWhatsNew(directives []DocDirective, manifest *InputsManifest, path, anchor string, row *TableRow) {
+	current, err := ParseVersion(manifest.CurrentVersion)
+	if err != nil {
+		return
+	}
+
+	for _, attr := range directives {
+		if attr.Parsed.Type != DirSince || attr.Inherited || (attr.Parsed.Flags&IsValid) == 0 {
+			continue
+		}
+
+		if since, err := ParseVersion(attr.RawContent); err == nil && since.Compare(current) == 0 {
+			row.NewIn = manifest.CurrentVersion
+			manifest.WhatsNew = append(manifest.WhatsNew, WhatsNewEntry{
+				Path:        path,
+				Anchor:      anchor,
+				Description: firstLine(row.Description),
 			})
+
+			return
 		}
 	}
 }
 
+func firstLine(str string) string {
+	line, _, _ := strings.Cut(str, "\n")
+
+	return line
+}
+
 func getArgOrDefault(args []string, index int) string {
 	if len(args) > index {
 		return args[index]
@@ -173,16 +586,28 @@ func getArgOrDefault(args []string, index int) string {
 	return ""
 }
 
-func recordNested(group ObjectField, manifest *InputsManifest) {
+func recordNested(group ObjectField, manifest *InputsManifest, scope directiveScope) {
 	if group.NestedDataType == nil {
 		return
 	}
 
+	directives := inheritDirectives(group.Documentation.Directives, scope)
+	groupLoc := scope.locate(group.Name).suppress(group.Documentation.Directives)
+	children := directiveScope{
+		path:       groupLoc.path,
+		position:   scope.position,
+		inherited:  directives,
+		suppressed: groupLoc.suppressed,
+		options:    scope.options,
+	}
+
 	if group.Fields != nil && len(group.Fields) > 0 {
 		data := newTableData()
 		data.Description = strings.Join(group.Documentation.Content, "\n")
+		data.MapKey = group.MapKey
 
-		processDirectives(group.Documentation.Directives, manifest, &data, nil)
+		processDirectives(scope.options.visibleDirectives(directives), manifest, &data, nil)
+		recordReferenceUses(data.Description, manifest, groupLoc)
 
 		for _, field := range group.Fields {
 			defaultValue := ""
@@ -197,7 +622,17 @@ func recordNested(group ObjectField, manifest *InputsManifest) {
 				row.ComplexType = field.NestedDataType
 			}
 
-			processDirectives(field.Documentation.Directives, manifest, nil, &row)
+			fieldDirectives := inheritDirectives(field.Documentation.Directives, children)
+			fieldLoc := children.locate(field.Name).suppress(field.Documentation.Directives)
+
+			visible := scope.options.visibleDirectives(fieldDirectives)
+
+			processDirectives(visible, manifest, nil, &row)
+			mergeInferredConstraints(scope.options.InferredConstraints, fieldLoc.path, &row.RowMetadata)
+			diagnoseDirectives(fieldDirectives, manifest, fieldLoc)
+			diagnoseRow("field", &row, manifest, fieldLoc)
+			recordMapKey(field.MapKey, field.Documentation.Directives, manifest, fieldLoc, &row)
+			recordWhatsNew(visible, manifest, fieldLoc.path, strings.ToLower(*group.NestedDataType), &row)
 
 			data.Rows = append(data.Rows, row)
 		}
@@ -206,12 +641,20 @@ func recordNested(group ObjectField, manifest *InputsManifest) {
 	}
 
 	for _, field := range group.Fields {
-		recordNested(field, manifest)
+		recordNested(field, manifest, children)
 	}
 }
 
+// ParseModuleInputsIntoManifest builds an InputsManifest using DefaultManifestOptions
 func ParseModuleInputsIntoManifest(inputs []*terraform.Input) *InputsManifest {
+	return ParseModuleInputsIntoManifestWithOptions(inputs, DefaultManifestOptions())
+}
+
+// ParseModuleInputsIntoManifestWithOptions builds an InputsManifest from the
+// inputs of a module loaded by terraform-docs.
+func ParseModuleInputsIntoManifestWithOptions(inputs []*terraform.Input, options ManifestOptions) *InputsManifest {
 	templateData := newTemplateData()
+	templateData.CurrentVersion = options.CurrentVersion
 
 	for _, input := range inputs {
 		var extras ObjectGroup
@@ -226,25 +669,79 @@ func ParseModuleInputsIntoManifest(inputs []*terraform.Input) *InputsManifest {
 		docBlk := parseStringIntoDocBlock(string(input.Description))
 		tableRow := newTableRow(string(input.Type), input.Name, input.GetValue(), strings.Join(docBlk.Content, "\n"))
 
-		processDirectives(docBlk.Directives, templateData, nil, &tableRow)
+		scope := directiveScope{
+			position:  input.Position,
+			inherited: docBlk.Directives,
+			options:   &options,
+		}
+		inputLoc := scope.locate(input.Name).suppress(docBlk.Directives)
+		scope.suppressed = inputLoc.suppressed
+
+		visible := options.visibleDirectives(docBlk.Directives)
+
+		processDirectives(visible, templateData, nil, &tableRow)
+		mergeInferredConstraints(options.InferredConstraints, inputLoc.path, &tableRow.RowMetadata)
+		diagnoseDirectives(docBlk.Directives, templateData, inputLoc)
+		diagnoseRow("variable", &tableRow, templateData, inputLoc)
+		recordWhatsNew(visible, templateData, inputLoc.path, "", &tableRow)
+
+		for _, validation := range options.Validations {
+			if validation.Variable == input.Name {
+				tableRow.Validations = append(tableRow.Validations, validation)
+			}
+		}
 
 		if extras.ObjectField.NestedDataType != nil {
 			tableRow.Type = extras.ObjectField.DataTypeStr
 			tableRow.ComplexType = extras.ObjectField.NestedDataType
 		}
 
+		if isMapTypeStr(string(input.Type)) {
+			extras.MapKey = parseMapKey(docBlk.Directives)
+		}
+
+		recordMapKey(extras.MapKey, docBlk.Directives, templateData, inputLoc, &tableRow)
+
 		if input.Required {
 			templateData.RequiredInputs.Rows = append(templateData.RequiredInputs.Rows, tableRow)
 		} else {
 			templateData.OptionalInputs.Rows = append(templateData.OptionalInputs.Rows, tableRow)
 		}
 
-		recordNested(extras.ObjectField, templateData)
-
-		for _, field := range extras.ObjectField.Fields {
-			recordNested(field, templateData)
-		}
+		// recordNested walks every nested field, inheriting from the variable's description
+		recordNested(extras.ObjectField, templateData, scope)
 	}
 
 	return templateData
 }
+
+// ParseModuleOutputsIntoManifest documents the outputs of a module loaded by
+// terraform-docs in a manifest built by ParseModuleInputsIntoManifest. Output
+// descriptions support the same directives as variable descriptions.
+func ParseModuleOutputsIntoManifest(outputs []*terraform.Output, manifest *InputsManifest) {
+	ParseModuleOutputsIntoManifestWithOptions(outputs, manifest, DefaultManifestOptions())
+}
+
+// ParseModuleOutputsIntoManifestWithOptions documents the outputs of a module in
+// a manifest built by ParseModuleInputsIntoManifestWithOptions.
+func ParseModuleOutputsIntoManifestWithOptions(outputs []*terraform.Output, manifest *InputsManifest, options ManifestOptions) {
+	for _, output := range outputs {
+		docBlk := parseStringIntoDocBlock(string(output.Description))
+		tableRow := newTableRow("", output.Name, "", strings.Join(docBlk.Content, "\n"))
+
+		loc := diagnosticLocation{
+			path:     "output." + output.Name,
+			position: output.Position,
+		}
+		loc = loc.suppress(docBlk.Directives)
+
+		visible := options.visibleDirectives(docBlk.Directives)
+
+		processDirectives(visible, manifest, nil, &tableRow)
+		diagnoseDirectives(docBlk.Directives, manifest, loc)
+		diagnoseRow("output", &tableRow, manifest, loc)
+		recordWhatsNew(visible, manifest, loc.path, "", &tableRow)
+
+		manifest.Outputs.Rows = append(manifest.Outputs.Rows, tableRow)
+	}
+}
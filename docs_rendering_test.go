@@ -0,0 +1,283 @@
+package tfdocextras
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/terraform-docs/terraform-docs/print"
+	"github.com/terraform-docs/terraform-docs/terraform"
+)
+
+func loadTestModule(t *testing.T, name string) *terraform.Module {
+	t.Helper()
+
+	config := print.DefaultConfig()
+	config.ModuleRoot = "testdata/" + name
+
+	module, err := terraform.LoadWithOptions(config)
+	if err != nil {
+		t.Fatalf("Failed to load module %s: %v", name, err)
+	}
+
+	return module
+}
+
+func findRow(t *testing.T, data TableData, name string) TableRow {
+	t.Helper()
+
+	for _, row := range data.Rows {
+		if row.Name == name {
+			return row
+		}
+	}
+
+	t.Fatalf("Row %s not found", name)
+	return TableRow{}
+}
+
+func TestParseModuleInputsIntoManifest_InheritsDirectives(t *testing.T) {
+	module := loadTestModule(t, "inheritance")
+	manifest := ParseModuleInputsIntoManifest(module.Inputs)
+
+	ownerGroupId := findRow(t, manifest.NestedInputs["AccessPoints"], "owner_group_id")
+	expected := []TableRowAttribute{
+		{Name: "Since", Content: "2.0.0", Inherited: true},
+	}
+
+	if diff := deep.Equal(ownerGroupId.Attributes, expected); diff != nil {
+		t.Errorf("Attributes mismatch:\n%v", diff)
+	}
+
+	// The example directive is not inheritable
+	if len(ownerGroupId.Examples) != 0 {
+		t.Errorf("Expected no inherited examples, got %+v", ownerGroupId.Examples)
+	}
+
+	owner := findRow(t, manifest.NestedInputs["Permissions"], "owner")
+	expected = []TableRowAttribute{
+		{Name: "Deprecated", Content: "Use `owner_group_id` instead"},
+		{Name: "Since", Content: "2.1.0", Inherited: true},
+		{Name: "Stability", Content: "beta", Inherited: true},
+	}
+
+	if diff := deep.Equal(owner.Attributes, expected); diff != nil {
+		t.Errorf("Attributes mismatch:\n%v", diff)
+	}
+}
+
+func TestParseModuleInputsIntoManifestWithOptions_NoInheritance(t *testing.T) {
+	module := loadTestModule(t, "inheritance")
+	manifest := ParseModuleInputsIntoManifestWithOptions(module.Inputs, ManifestOptions{})
+
+	mode := findRow(t, manifest.NestedInputs["Permissions"], "mode")
+
+	if len(mode.Attributes) != 0 {
+		t.Errorf("Expected no attributes, got %+v", mode.Attributes)
+	}
+}
+
+func TestParseModuleInputsIntoManifestWithOptions_WhatsNew(t *testing.T) {
+	module := loadTestModule(t, "versions")
+
+	options := DefaultManifestOptions()
+	options.CurrentVersion = "1.2.0"
+	manifest := ParseModuleInputsIntoManifestWithOptions(module.Inputs, options)
+
+	expected := []WhatsNewEntry{
+		{Path: "lifecycle_policy", Description: "Configures the lifecycle policy."},
+		{Path: "throughput_mode", Description: "The throughput mode for the file system."},
+	}
+
+	if diff := deep.Equal(manifest.WhatsNew, expected); diff != nil {
+		t.Errorf("WhatsNew mismatch:\n%v", diff)
+	}
+
+	if row := findRow(t, manifest.OptionalInputs, "throughput_mode"); row.NewIn != "1.2.0" {
+		t.Errorf("Expected throughput_mode to be new in 1.2.0, got %q", row.NewIn)
+	}
+
+	// Inherited versions are not highlighted individually
+	if row := findRow(t, manifest.NestedInputs["LifecyclePolicy"], "transition_to_archive"); row.NewIn != "" {
+		t.Errorf("Expected transition_to_archive not to be highlighted, got %q", row.NewIn)
+	}
+}
+
+func TestParseModuleInputsIntoManifestWithOptions_HiddenDirectives(t *testing.T) {
+	module := loadTestModule(t, "versions")
+
+	options := DefaultManifestOptions()
+	options.CurrentVersion = "1.2.0"
+	options.HiddenDirectives = []string{"since"}
+	manifest := ParseModuleInputsIntoManifestWithOptions(module.Inputs, options)
+
+	if row := findRow(t, manifest.OptionalInputs, "throughput_mode"); len(row.Attributes) != 0 || row.NewIn != "" {
+		t.Errorf("Expected the since directive to be hidden, got %+v", row)
+	}
+
+	if len(manifest.WhatsNew) != 0 {
+		t.Errorf("Expected no WhatsNew entries, got %+v", manifest.WhatsNew)
+	}
+
+	// Hidden directives are still diagnosed
+	if len(manifest.Diagnostics) != 1 {
+		t.Errorf("Expected 1 diagnostic, got %+v", manifest.Diagnostics)
+	}
+}
+
+func TestParseModuleInputsIntoManifest_InvalidSinceDiagnostic(t *testing.T) {
+	module := loadTestModule(t, "versions")
+	manifest := ParseModuleInputsIntoManifest(module.Inputs)
+
+	if len(manifest.Diagnostics) != 1 {
+		t.Fatalf("Expected 1 diagnostic, got %+v", manifest.Diagnostics)
+	}
+
+	diagnostic := manifest.Diagnostics[0]
+
+	if diagnostic.Path != "name" || diagnostic.Code != DiagInvalidDirective || diagnostic.Line != 1 {
+		t.Errorf("Unexpected diagnostic: %+v", diagnostic)
+	}
+}
+
+func TestParseModuleInputsIntoManifest_MultipleRegexConstraints(t *testing.T) {
+	module := loadTestModule(t, "constraints")
+	manifest := ParseModuleInputsIntoManifest(module.Inputs)
+
+	row := findRow(t, manifest.RequiredInputs, "alarm_condition")
+	expected := []RegexConstraint{
+		{
+			Pattern:     `^(Average|Minimum|Maximum) (<=|<|>=|>) \d+$`,
+			Description: "Statistic, operator, and threshold",
+			Examples:    []string{`"Average >= 20"`, `"Minimum < 10"`},
+		},
+		{
+			Pattern:  `(?i)^maximum`,
+			Examples: []string{`"Maximum <= 100"`, `"Average > 5"`},
+		},
+	}
+
+	if diff := deep.Equal(row.RegexConstraints, expected); diff != nil {
+		t.Errorf("RegexConstraints mismatch:\n%v", diff)
+	}
+
+	if len(manifest.Diagnostics) != 1 || manifest.Diagnostics[0].Code != DiagRegexExampleMismatch {
+		t.Fatalf("Expected a single regex mismatch diagnostic, got %+v", manifest.Diagnostics)
+	}
+
+	if manifest.Diagnostics[0].Path != "alarm_condition" {
+		t.Errorf("Unexpected diagnostic path %q", manifest.Diagnostics[0].Path)
+	}
+}
+
+func TestParseModuleInputsIntoManifest_MapKeys(t *testing.T) {
+	module := loadTestModule(t, "mapkeys")
+	manifest := ParseModuleInputsIntoManifest(module.Inputs)
+
+	expected := &MapKeyDoc{
+		Description: "The name of the access point",
+		Pattern:     "^[a-z-]+$",
+		Examples:    []string{`"my-ap"`, `"fs-ap"`},
+	}
+
+	if diff := deep.Equal(findRow(t, manifest.OptionalInputs, "access_points").MapKey, expected); diff != nil {
+		t.Errorf("Input MapKey mismatch:\n%v", diff)
+	}
+
+	if diff := deep.Equal(manifest.NestedInputs["AccessPoints"].MapKey, expected); diff != nil {
+		t.Errorf("Table MapKey mismatch:\n%v", diff)
+	}
+
+	expected = &MapKeyDoc{
+		Description: "Tag name",
+		Pattern:     "^[A-Za-z]+$",
+		Examples:    []string{"Name", "Environment"},
+	}
+
+	if diff := deep.Equal(findRow(t, manifest.NestedInputs["AccessPoints"], "tags").MapKey, expected); diff != nil {
+		t.Errorf("Field MapKey mismatch:\n%v", diff)
+	}
+
+	if len(manifest.Diagnostics) != 1 || manifest.Diagnostics[0].Code != DiagMisplacedDirective || manifest.Diagnostics[0].Path != "access_points.path" {
+		t.Errorf("Expected a misplaced @key diagnostic, got %+v", manifest.Diagnostics)
+	}
+}
+
+func TestParseModuleOutputsIntoManifest(t *testing.T) {
+	module := loadTestModule(t, "outputs")
+	manifest := ParseModuleInputsIntoManifest(module.Inputs)
+	ParseModuleOutputsIntoManifest(module.Outputs, manifest)
+
+	fileSystemId := findRow(t, manifest.Outputs, "file_system_id")
+
+	if fileSystemId.Description != "The ID of the file system." {
+		t.Errorf("Unexpected description %q", fileSystemId.Description)
+	}
+
+	if diff := deep.Equal(fileSystemId.Attributes, []TableRowAttribute{{Name: "Since", Content: "1.0.0"}}); diff != nil {
+		t.Errorf("Attributes mismatch:\n%v", diff)
+	}
+
+	if len(manifest.Outputs.Rows) != 2 {
+		t.Errorf("Expected 2 outputs, got %d", len(manifest.Outputs.Rows))
+	}
+}
+
+func TestDiagnoseReferenceLinks_DefinedByOutput(t *testing.T) {
+	// The mount_targets variable uses a reference link defined by an output
+	module := loadTestModule(t, "outputs")
+	manifest := ParseModuleInputsIntoManifest(module.Inputs)
+	ParseModuleOutputsIntoManifest(module.Outputs, manifest)
+	manifest.DiagnoseReferenceLinks()
+
+	if len(manifest.Diagnostics) != 0 {
+		t.Errorf("Expected no diagnostics, got %+v", manifest.Diagnostics)
+	}
+
+	if manifest.ReferenceLinks["mount-targets"] == "" {
+		t.Errorf("Expected the output's reference link, got %v", manifest.ReferenceLinks)
+	}
+}
+
+func TestParseModuleInputsIntoManifest_LintRules(t *testing.T) {
+	// The replication variable suppresses every rule for itself and its fields
+	module := loadTestModule(t, "lint")
+	manifest := ParseModuleInputsIntoManifest(module.Inputs)
+	manifest.DiagnoseReferenceLinks()
+
+	var actual []string
+	for _, diagnostic := range manifest.Diagnostics {
+		actual = append(actual, diagnostic.Path+" "+diagnostic.Code)
+	}
+
+	expected := []string{
+		"lifecycle_policy.transition_to_ia undocumented",
+		"lifecycle_policy.transition_to_archive enum-default-mismatch",
+		"throughput_mode duplicate-directive",
+		"throughput_mode unknown-directive",
+		"throughput_mode enum-default-mismatch",
+		"undocumented undocumented",
+		"throughput_mode undefined-reference-link",
+	}
+
+	if diff := deep.Equal(actual, expected); diff != nil {
+		t.Errorf("Diagnostics mismatch:\n%v\n%v", diff, manifest.Diagnostics)
+	}
+}
+
+func TestParseModuleInputsIntoManifest_QuotedEnumValues(t *testing.T) {
+	// Enum values render as written, and are compared to defaults unquoted
+	module := loadTestModule(t, "lint")
+	manifest := ParseModuleInputsIntoManifest(module.Inputs)
+
+	quoteStyle := findRow(t, manifest.OptionalInputs, "quote_style")
+
+	if diff := deep.Equal(quoteStyle.Enumerations, []string{`"it\"s"`, `'a|b'`, "plain"}); diff != nil {
+		t.Errorf("Enumerations mismatch:\n%v", diff)
+	}
+
+	for _, diagnostic := range manifest.Diagnostics {
+		if diagnostic.Path == "quote_style" {
+			t.Errorf("Unexpected diagnostic %v", diagnostic)
+		}
+	}
+}
@@ -0,0 +1,340 @@
+package tfdocextras
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/terraform-docs/terraform-docs/terraform"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// ExampleVariant selects the inputs and fields an example includes
+type ExampleVariant string
+
+const (
+	// ExampleMinimal includes the required inputs and fields only
+	ExampleMinimal ExampleVariant = "minimal"
+
+	// ExampleFull includes the optional inputs and fields as well, commented
+	// out with their defaults
+	ExampleFull ExampleVariant = "full"
+)
+
+// exampleWriter writes the attributes of an example
+type exampleWriter struct {
+	variant ExampleVariant
+	body    strings.Builder
+}
+
+// GenerateExampleVariables returns a `.tfvars` file setting the inputs of a
+// module loaded by terraform-docs, as a starting point for its consumers.
+// Required inputs and fields are set to a placeholder of their type, which is
+// the first `@enum` value or `@regex` example when there is one. In the full
+// variant, optional inputs and fields are commented out with their defaults,
+// except those marked `@deprecated` or `@internal`. Every attribute is preceded
+// by the first line of its doc block and the values allowed by its `@enum`.
+func GenerateExampleVariables(inputs []*terraform.Input, variant ExampleVariant) []byte {
+	return hclwrite.Format([]byte(exampleBody(inputs, variant)))
+}
+
+// GenerateExampleModule returns a `module` block called name, calling the
+// module at source with the inputs GenerateExampleVariables would set
+func GenerateExampleModule(inputs []*terraform.Input, name, source string, variant ExampleVariant) []byte {
+	content := "module " + hclString(name) + " {\nsource = " + hclString(source) + "\n"
+
+	if body := exampleBody(inputs, variant); body != "" {
+		content += "\n" + body
+	}
+
+	return hclwrite.Format([]byte(content + "}\n"))
+}
+
+// exampleBody returns the unformatted attributes of an example, required inputs
+// first
+func exampleBody(inputs []*terraform.Input, variant ExampleVariant) string {
+	writer := exampleWriter{variant: variant}
+
+	for _, required := range []bool{true, false} {
+		for _, input := range inputs {
+			if input.Required != required {
+				continue
+			}
+
+			ty, defaults := inputType(input)
+			field := inputField(input)
+
+			value := cty.NilVal
+			if !input.Required {
+				value = inputDefault(input)
+			}
+
+			if writer.omits(&field, !input.Required) {
+				continue
+			}
+
+			if writer.body.Len() > 0 {
+				writer.body.WriteString("\n")
+			}
+
+			writer.attribute(input.Name, ty, defaults, &field, !input.Required, value)
+		}
+	}
+
+	return writer.body.String()
+}
+
+// inputDefault returns the default value of an optional input, null when it
+// cannot be decoded
+func inputDefault(input *terraform.Input) cty.Value {
+	content := []byte(input.GetValue())
+
+	ty, err := ctyjson.ImpliedType(content)
+	if err != nil {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+
+	value, err := ctyjson.Unmarshal(content, ty)
+	if err != nil {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+
+	return value
+}
+
+// omits reports whether the example leaves out an attribute documented by field
+func (w *exampleWriter) omits(field *ObjectField, optional bool) bool {
+	return optional && (w.variant != ExampleFull || field.hasDirective(DirDeprecated) || field.hasDirective(DirInternal))
+}
+
+// attribute writes a variable or object attribute. Optional ones are commented
+// out with their default value, null when value is cty.NilVal.
+func (w *exampleWriter) attribute(name string, ty cty.Type, defaults *typeexpr.Defaults, field *ObjectField, optional bool, value cty.Value) {
+	if w.omits(field, optional) {
+		return
+	}
+
+	w.comments(ty, field)
+
+	if !optional {
+		w.body.WriteString(exampleKey(name) + " = ")
+		w.value(ty, defaults, field)
+		w.body.WriteString("\n")
+
+		return
+	}
+
+	if value == cty.NilVal {
+		value = cty.NullVal(cty.DynamicPseudoType)
+	}
+
+	defaultValue := string(hclwrite.TokensForValue(value).Bytes())
+	example := exampleWriter{variant: w.variant}
+	example.body.WriteString(exampleKey(name) + " = ")
+
+	// Empty defaults would hide the structure of objects, show it instead
+	if isEmptyValue(value) && containsObject(ty) {
+		w.body.WriteString("# Default: " + defaultValue + "\n")
+		example.value(ty, defaults, field)
+	} else {
+		example.body.WriteString(defaultValue)
+	}
+
+	formatted := hclwrite.Format([]byte(example.body.String()))
+
+	for _, line := range strings.Split(strings.TrimSpace(string(formatted)), "\n") {
+		w.body.WriteString("# " + line + "\n")
+	}
+}
+
+// isEmptyValue reports whether value is null or an empty collection
+func isEmptyValue(value cty.Value) bool {
+	if value.IsNull() || !value.IsKnown() {
+		return true
+	}
+
+	return value.CanIterateElements() && value.LengthInt() == 0
+}
+
+// containsObject reports whether values of type ty hold objects
+func containsObject(ty cty.Type) bool {
+	switch {
+	case ty.IsObjectType():
+		return true
+	case ty.IsListType() || ty.IsSetType() || ty.IsMapType():
+		return containsObject(ty.ElementType())
+	case ty.IsTupleType():
+		return slices.ContainsFunc(ty.TupleElementTypes(), containsObject)
+	}
+
+	return false
+}
+
+// comments writes the first line of the doc block of field and the values
+// allowed by its `@enum`
+func (w *exampleWriter) comments(ty cty.Type, field *ObjectField) {
+	if field == nil {
+		return
+	}
+
+	if summary := strings.TrimSpace(firstLine(strings.Join(field.Documentation.Content, "\n"))); summary != "" {
+		w.body.WriteString("# " + summary + "\n")
+	}
+
+	if choices := enumChoices(ty, field); len(choices) > 0 {
+		w.body.WriteString("# Allowed values: " + strings.Join(choices, ", ") + "\n")
+	}
+}
+
+// value writes a placeholder of type ty
+func (w *exampleWriter) value(ty cty.Type, defaults *typeexpr.Defaults, field *ObjectField) {
+	switch {
+	case ty.IsPrimitiveType():
+		w.body.WriteString(placeholder(ty, field))
+
+	case ty.IsObjectType():
+		w.body.WriteString("{\n")
+
+		for _, name := range attributeOrder(ty, field) {
+			value := cty.NilVal
+			if defaults != nil {
+				if defaultValue, ok := defaults.DefaultValues[name]; ok {
+					value = defaultValue
+				}
+			}
+
+			w.attribute(name, ty.AttributeType(name), childDefaults(defaults, name), field.child(name), ty.AttributeOptional(name), value)
+		}
+
+		w.body.WriteString("}")
+
+	// Collections of primitive values are left empty, while those of objects
+	// show the structure of an element
+	case (ty.IsListType() || ty.IsSetType()) && !ty.ElementType().IsPrimitiveType():
+		w.body.WriteString("[\n")
+		w.value(ty.ElementType(), childDefaults(defaults, ""), field)
+		w.body.WriteString(",\n]")
+	case ty.IsListType() || ty.IsSetType():
+		w.body.WriteString("[]")
+
+	case ty.IsMapType() && !ty.ElementType().IsPrimitiveType():
+		key := "key"
+		if field != nil && field.MapKey != nil && len(field.MapKey.Examples) > 0 {
+			key = unquoteDirectiveArgs(field.MapKey.Examples[:1])[0]
+		}
+
+		w.body.WriteString("{\n" + exampleKey(key) + " = ")
+		w.value(ty.ElementType(), childDefaults(defaults, ""), field)
+		w.body.WriteString("\n}")
+	case ty.IsMapType():
+		w.body.WriteString("{}")
+
+	case ty.IsTupleType():
+		w.body.WriteString("[")
+
+		for i, elementType := range ty.TupleElementTypes() {
+			if i > 0 {
+				w.body.WriteString(", ")
+			}
+
+			w.value(elementType, nil, nil)
+		}
+
+		w.body.WriteString("]")
+
+	default:
+		w.body.WriteString("null")
+	}
+}
+
+// attributeOrder returns the attribute names of an object type in the order
+// field declares them, followed by the undocumented ones sorted by name
+func attributeOrder(ty cty.Type, field *ObjectField) []string {
+	var names []string
+
+	if field != nil {
+		for _, child := range field.Fields {
+			if ty.HasAttribute(child.Name) {
+				names = append(names, child.Name)
+			}
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(ty.AttributeTypes())) {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// placeholder returns the literal of a primitive value allowed by the `@enum`
+// or `@regex` directives of field
+func placeholder(ty cty.Type, field *ObjectField) string {
+	if choices := enumChoices(ty, field); len(choices) > 0 {
+		return choices[0]
+	}
+
+	if field != nil && ty.Equals(cty.String) {
+		for _, attr := range field.Documentation.Directives {
+			if attr.Parsed.Type == DirRegex && (attr.Parsed.Flags&IsValid) != 0 && len(attr.Parsed.Args) > 1 {
+				return hclString(unquoteDirectiveArgs(attr.Parsed.Args[1:2])[0])
+			}
+		}
+	}
+
+	switch {
+	case ty.Equals(cty.Number):
+		return "0"
+	case ty.Equals(cty.Bool):
+		return "false"
+	}
+
+	return `""`
+}
+
+// enumChoices returns the literals of the `@enum` values of field, which apply
+// to the primitive elements of collections
+func enumChoices(ty cty.Type, field *ObjectField) []string {
+	if field == nil {
+		return nil
+	}
+
+	for ty.IsListType() || ty.IsSetType() || ty.IsMapType() {
+		ty = ty.ElementType()
+	}
+
+	if !ty.IsPrimitiveType() {
+		return nil
+	}
+
+	for _, attr := range field.Documentation.Directives {
+		if attr.Parsed.Type != DirEnum || (attr.Parsed.Flags&IsValid) == 0 {
+			continue
+		}
+
+		choices := enumValues(attr.Parsed.Args)
+		for i, choice := range choices {
+			choices[i] = primitiveLiteral(ty, choice)
+		}
+
+		return choices
+	}
+
+	return nil
+}
+
+// exampleKey returns name as an attribute name, quoted unless it is a valid
+// identifier
+func exampleKey(name string) string {
+	if hclsyntax.ValidIdentifier(name) {
+		return name
+	}
+
+	return hclString(name)
+}
@@ -0,0 +1,86 @@
+package tfdocextras
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestGenerateExampleVariables(t *testing.T) {
+	module := loadTestModule(t, "examples")
+
+	expected := `# The name of the cluster.
+name = "my-cluster"
+
+# The default node pool
+node_pool = {
+  # The instance type of the nodes
+  instance_type = ""
+  # The number of nodes
+  # Allowed values: 1, 3, 5
+  # size = 3
+  # The labels of the nodes
+  # labels = null
+}
+
+# The listeners of the load balancer.
+# Default: {}
+# listeners = {
+#   web = {
+#     # The port to listen on
+#     port = 0
+#     # Allowed values: "HTTP", "HTTPS"
+#     # protocol = "HTTPS"
+#   }
+# }
+
+# The tags of the resources
+# tags = {
+#   Team = "platform"
+# }
+`
+
+	if diff := deep.Equal(string(GenerateExampleVariables(module.Inputs, ExampleFull)), expected); diff != nil {
+		t.Errorf("Full example mismatch:\n%v", diff)
+	}
+}
+
+func TestGenerateExampleVariables_Minimal(t *testing.T) {
+	module := loadTestModule(t, "examples")
+
+	expected := `# The name of the cluster.
+name = "my-cluster"
+
+# The default node pool
+node_pool = {
+  # The instance type of the nodes
+  instance_type = ""
+}
+`
+
+	if diff := deep.Equal(string(GenerateExampleVariables(module.Inputs, ExampleMinimal)), expected); diff != nil {
+		t.Errorf("Minimal example mismatch:\n%v", diff)
+	}
+}
+
+func TestGenerateExampleModule(t *testing.T) {
+	module := loadTestModule(t, "examples")
+
+	expected := `module "cluster" {
+  source = "../modules/cluster"
+
+  # The name of the cluster.
+  name = "my-cluster"
+
+  # The default node pool
+  node_pool = {
+    # The instance type of the nodes
+    instance_type = ""
+  }
+}
+`
+
+	if diff := deep.Equal(string(GenerateExampleModule(module.Inputs, "cluster", "../modules/cluster", ExampleMinimal)), expected); diff != nil {
+		t.Errorf("Module block mismatch:\n%v", diff)
+	}
+}
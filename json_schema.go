@@ -0,0 +1,231 @@
+package tfdocextras
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/terraform-docs/terraform-docs/terraform"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// JSONSchemaDialect is the JSON Schema draft GenerateJSONSchema follows
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema is the subset of JSON Schema needed to describe Terraform values
+type JSONSchema struct {
+	Schema      string `json:"$schema,omitempty"`
+	Description string `json:"description,omitempty"`
+	Deprecated  bool   `json:"deprecated,omitempty"`
+	Type        string `json:"type,omitempty"`
+
+	Properties map[string]*JSONSchema `json:"properties,omitempty"`
+	Required   []string               `json:"required,omitempty"`
+
+	// AdditionalProperties is false for objects, and the schema of the values
+	// of maps
+	AdditionalProperties any         `json:"additionalProperties,omitempty"`
+	PropertyNames        *JSONSchema `json:"propertyNames,omitempty"`
+
+	Items       *JSONSchema   `json:"items,omitempty"`
+	PrefixItems []*JSONSchema `json:"prefixItems,omitempty"`
+	MinItems    int           `json:"minItems,omitempty"`
+	MaxItems    int           `json:"maxItems,omitempty"`
+	UniqueItems bool          `json:"uniqueItems,omitempty"`
+
+	Enum    []any         `json:"enum,omitempty"`
+	Pattern string        `json:"pattern,omitempty"`
+	AllOf   []*JSONSchema `json:"allOf,omitempty"`
+
+	Default json.RawMessage `json:"default,omitempty"`
+}
+
+// GenerateJSONSchema describes the inputs of a module loaded by terraform-docs
+// as a JSON Schema, which editors use to validate and complete
+// `terraform.tfvars.json` files. Descriptions, `@deprecated`, and the defaults
+// of optional attributes are carried over; `@enum` and `@regex` constrain the
+// primitive values of the field declaring them, including the elements of
+// collections, and `@key` constrains the keys of maps. Patterns are copied as
+// is, so they should stick to the syntax shared by Go and ECMAScript.
+func GenerateJSONSchema(inputs []*terraform.Input) *JSONSchema {
+	root := &JSONSchema{
+		Schema:               JSONSchemaDialect,
+		Type:                 "object",
+		Properties:           map[string]*JSONSchema{},
+		AdditionalProperties: false,
+	}
+
+	for _, input := range inputs {
+		ty, defaults := inputType(input)
+		field := inputField(input)
+		schema := fieldSchema(ty, defaults, &field)
+
+		if input.Required {
+			root.Required = append(root.Required, input.Name)
+		} else if value := input.GetValue(); value != "" && value != "null" {
+			schema.Default = json.RawMessage(value)
+		}
+
+		root.Properties[input.Name] = schema
+	}
+
+	return root
+}
+
+// fieldSchema returns the schema of a variable or object attribute, documented
+// by field when it is not nil
+func fieldSchema(ty cty.Type, defaults *typeexpr.Defaults, field *ObjectField) *JSONSchema {
+	schema := typeSchema(ty, defaults, field)
+
+	if field == nil {
+		return schema
+	}
+
+	schema.Description = strings.Join(field.Documentation.Content, "\n")
+	schema.Deprecated = slices.ContainsFunc(field.Documentation.Directives, func(attr DocDirective) bool {
+		return attr.Parsed.Type == DirDeprecated
+	})
+
+	return schema
+}
+
+// typeSchema returns the schema of the values of type ty. The directives of
+// field apply to its primitive values.
+func typeSchema(ty cty.Type, defaults *typeexpr.Defaults, field *ObjectField) *JSONSchema {
+	switch {
+	case ty.Equals(cty.String):
+		return directiveSchema(&JSONSchema{Type: "string"}, field)
+	case ty.Equals(cty.Number):
+		return directiveSchema(&JSONSchema{Type: "number"}, field)
+	case ty.Equals(cty.Bool):
+		return directiveSchema(&JSONSchema{Type: "boolean"}, field)
+
+	case ty.IsObjectType():
+		schema := &JSONSchema{
+			Type:                 "object",
+			Properties:           map[string]*JSONSchema{},
+			AdditionalProperties: false,
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(ty.AttributeTypes())) {
+			property := fieldSchema(ty.AttributeType(name), childDefaults(defaults, name), field.child(name))
+
+			if defaults != nil {
+				if value, ok := defaults.DefaultValues[name]; ok && !value.IsNull() {
+					if content, err := ctyjson.Marshal(value, value.Type()); err == nil {
+						property.Default = content
+					}
+				}
+			}
+
+			if !ty.AttributeOptional(name) {
+				schema.Required = append(schema.Required, name)
+			}
+
+			schema.Properties[name] = property
+		}
+
+		return schema
+
+	case ty.IsListType() || ty.IsSetType():
+		return &JSONSchema{
+			Type:        "array",
+			Items:       typeSchema(ty.ElementType(), childDefaults(defaults, ""), field),
+			UniqueItems: ty.IsSetType(),
+		}
+
+	case ty.IsMapType():
+		schema := &JSONSchema{
+			Type:                 "object",
+			AdditionalProperties: typeSchema(ty.ElementType(), childDefaults(defaults, ""), field),
+		}
+
+		if field != nil && field.MapKey != nil && field.MapKey.Pattern != "" {
+			schema.PropertyNames = &JSONSchema{Pattern: field.MapKey.Pattern}
+		}
+
+		return schema
+
+	case ty.IsTupleType():
+		schema := &JSONSchema{
+			Type:     "array",
+			MinItems: ty.Length(),
+			MaxItems: ty.Length(),
+		}
+
+		for i, elementType := range ty.TupleElementTypes() {
+			schema.PrefixItems = append(schema.PrefixItems, typeSchema(elementType, childDefaults(defaults, strconv.Itoa(i)), field))
+		}
+
+		return schema
+	}
+
+	// Values of type "any" are not constrained
+	return &JSONSchema{}
+}
+
+func childDefaults(defaults *typeexpr.Defaults, key string) *typeexpr.Defaults {
+	if defaults == nil {
+		return nil
+	}
+
+	return defaults.Children[key]
+}
+
+// directiveSchema constrains a primitive schema with the `@enum` and `@regex`
+// directives of field
+func directiveSchema(schema *JSONSchema, field *ObjectField) *JSONSchema {
+	if field == nil {
+		return schema
+	}
+
+	var patterns []string
+
+	for _, attr := range field.Documentation.Directives {
+		if (attr.Parsed.Flags & IsValid) == 0 {
+			continue
+		}
+
+		switch attr.Parsed.Type {
+		case DirEnum:
+			for _, choice := range enumValues(attr.Parsed.Args) {
+				schema.Enum = append(schema.Enum, enumValue(schema.Type, choice))
+			}
+		case DirRegex:
+			if schema.Type == "string" {
+				patterns = append(patterns, attr.Parsed.Args[0])
+			}
+		}
+	}
+
+	// A schema has a single pattern, further patterns must match as well
+	for i, pattern := range patterns {
+		if i == 0 {
+			schema.Pattern = pattern
+		} else {
+			schema.AllOf = append(schema.AllOf, &JSONSchema{Pattern: pattern})
+		}
+	}
+
+	return schema
+}
+
+// enumValue converts an `@enum` value to the JSON type of the schema
+func enumValue(schemaType, choice string) any {
+	switch schemaType {
+	case "number":
+		if number, err := strconv.ParseFloat(choice, 64); err == nil {
+			return number
+		}
+	case "boolean":
+		if boolean, err := strconv.ParseBool(choice); err == nil {
+			return boolean
+		}
+	}
+
+	return choice
+}
@@ -0,0 +1,83 @@
+package tfdocextras
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestGenerateJSONSchema(t *testing.T) {
+	module := loadTestModule(t, "schema")
+	schema := GenerateJSONSchema(module.Inputs)
+
+	if schema.Schema != JSONSchemaDialect || schema.AdditionalProperties != false {
+		t.Errorf("Expected a closed 2020-12 root schema, got %+v", schema)
+	}
+
+	if diff := deep.Equal(schema.Required, []string{"name"}); diff != nil {
+		t.Errorf("Required mismatch:\n%v", diff)
+	}
+
+	expected := map[string]*JSONSchema{
+		"name": {
+			Description: "The name of the file system.",
+			Type:        "string",
+			Pattern:     "^[a-z][a-z0-9-]*$",
+		},
+		"throughput_mode": {
+			Description: "The throughput mode.",
+			Deprecated:  true,
+			Type:        "string",
+			Enum:        []any{"bursting", "elastic", "provisioned"},
+			Default:     json.RawMessage(`"bursting"`),
+		},
+		"access_points": {
+			Description: "Configures access points.",
+			Type:        "object",
+			AdditionalProperties: &JSONSchema{
+				Type: "object",
+				Properties: map[string]*JSONSchema{
+					"user": {
+						Description: "The POSIX user of the access point",
+						Type:        "object",
+						Properties: map[string]*JSONSchema{
+							"gid": {Type: "number"},
+							"uid": {Type: "number"},
+						},
+						Required:             []string{"gid", "uid"},
+						AdditionalProperties: false,
+					},
+					"permissions": {
+						Type:    "string",
+						Enum:    []any{"0755", "0750"},
+						Default: json.RawMessage(`"0755"`),
+					},
+					"secondary_gids": {
+						Type:        "array",
+						Items:       &JSONSchema{Type: "number"},
+						UniqueItems: true,
+						Default:     json.RawMessage(`[]`),
+					},
+				},
+				Required:             []string{"user"},
+				AdditionalProperties: false,
+			},
+			PropertyNames: &JSONSchema{Pattern: "^[a-z-]+$"},
+			Default:       json.RawMessage(`{}`),
+		},
+		"retention": {
+			Type:        "array",
+			PrefixItems: []*JSONSchema{{Type: "number"}, {Type: "string"}},
+			MinItems:    2,
+			MaxItems:    2,
+		},
+		"extra": {
+			Description: "Any value",
+		},
+	}
+
+	if diff := deep.Equal(schema.Properties, expected); diff != nil {
+		t.Errorf("Properties mismatch:\n%v", diff)
+	}
+}
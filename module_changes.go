@@ -0,0 +1,268 @@
+package tfdocextras
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/terraform-docs/terraform-docs/terraform"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+type ChangeSeverity string
+
+const (
+	// ChangeBreaking is a change that may break the configurations of consumers
+	ChangeBreaking ChangeSeverity = "breaking"
+
+	// ChangeMinor is a backward compatible change
+	ChangeMinor ChangeSeverity = "minor"
+)
+
+// Change codes identify the kind of change a ModuleChange reports
+const (
+	ChangeFieldAdded         = "field-added"
+	ChangeRequiredFieldAdded = "required-field-added"
+	ChangeFieldRemoved       = "field-removed"
+	ChangeTypeChanged        = "type-changed"
+	ChangeDefaultChanged     = "default-changed"
+	ChangeOptionalToRequired = "optional-to-required"
+	ChangeRequiredToOptional = "required-to-optional"
+	ChangeEnumAdded          = "enum-added"
+	ChangeEnumValueAdded     = "enum-value-added"
+	ChangeEnumValueRemoved   = "enum-value-removed"
+	ChangeOutputAdded        = "output-added"
+	ChangeOutputRemoved      = "output-removed"
+)
+
+// ModuleChange is a difference between two revisions of a module that affects
+// its consumers
+type ModuleChange struct {
+	Severity ChangeSeverity `json:"severity"`
+	Code     string         `json:"code"`
+	Path     string         `json:"path"`
+	Message  string         `json:"message"`
+}
+
+func (c ModuleChange) String() string {
+	return fmt.Sprintf("%s: %s: %s [%s]", c.Severity, c.Path, c.Message, c.Code)
+}
+
+// HasBreakingChanges reports whether any of the changes is breaking
+func HasBreakingChanges(changes []ModuleChange) bool {
+	return slices.ContainsFunc(changes, func(change ModuleChange) bool {
+		return change.Severity == ChangeBreaking
+	})
+}
+
+// moduleComparer collects the changes between two revisions of a module
+type moduleComparer struct {
+	changes []ModuleChange
+}
+
+func (c *moduleComparer) add(severity ChangeSeverity, code, path, message string) {
+	c.changes = append(c.changes, ModuleChange{
+		Severity: severity,
+		Code:     code,
+		Path:     path,
+		Message:  message,
+	})
+}
+
+// CompareModules returns the changes between two revisions of a module loaded by
+// terraform-docs, comparing their inputs field by field through nested objects
+// and collections, then their outputs. Changes that may break the
+// configurations of consumers are removed inputs, fields, and outputs, new
+// required inputs and fields, optional ones becoming required, type changes,
+// default changes, and `@enum` values removed or added to a field that had
+// none. Other changes are minor.
+func CompareModules(from, to *terraform.Module) []ModuleChange {
+	var c moduleComparer
+
+	fromInputs := inputsByName(from.Inputs)
+	toInputs := inputsByName(to.Inputs)
+
+	for _, name := range unionKeys(fromInputs, toInputs) {
+		fromInput, toInput := fromInputs[name], toInputs[name]
+
+		switch {
+		case toInput == nil:
+			c.add(ChangeBreaking, ChangeFieldRemoved, name, "the variable was removed")
+		case fromInput == nil && toInput.Required:
+			c.add(ChangeBreaking, ChangeRequiredFieldAdded, name, "a required variable was added")
+		case fromInput == nil:
+			c.add(ChangeMinor, ChangeFieldAdded, name, "an optional variable was added")
+		default:
+			fromType, fromDefaults := inputType(fromInput)
+			toType, toDefaults := inputType(toInput)
+			fromField, toField := inputField(fromInput), inputField(toInput)
+
+			c.compareOptionality(name, !fromInput.Required, !toInput.Required, inputDefault(fromInput), inputDefault(toInput))
+			c.compareType(name, fromType, toType, fromDefaults, toDefaults, &fromField, &toField)
+		}
+	}
+
+	fromOutputs := map[string]bool{}
+	for _, output := range from.Outputs {
+		fromOutputs[output.Name] = true
+	}
+
+	toOutputs := map[string]bool{}
+	for _, output := range to.Outputs {
+		toOutputs[output.Name] = true
+	}
+
+	for _, name := range unionKeys(fromOutputs, toOutputs) {
+		if !toOutputs[name] {
+			c.add(ChangeBreaking, ChangeOutputRemoved, "output."+name, "the output was removed")
+		} else if !fromOutputs[name] {
+			c.add(ChangeMinor, ChangeOutputAdded, "output."+name, "an output was added")
+		}
+	}
+
+	return c.changes
+}
+
+// compareOptionality compares whether a variable or attribute is optional in
+// both revisions, and its defaults when it is
+func (c *moduleComparer) compareOptionality(path string, fromOptional, toOptional bool, fromDefault, toDefault cty.Value) {
+	switch {
+	case fromOptional && !toOptional:
+		c.add(ChangeBreaking, ChangeOptionalToRequired, path, "the field is now required")
+	case !fromOptional && toOptional:
+		c.add(ChangeMinor, ChangeRequiredToOptional, path, "the field is now optional")
+	case fromOptional:
+		fromJSON, toJSON := defaultJSON(fromDefault), defaultJSON(toDefault)
+		if fromJSON != toJSON {
+			c.add(ChangeBreaking, ChangeDefaultChanged, path, "the default changed from "+fromJSON+" to "+toJSON)
+		}
+	}
+}
+
+// compareType compares the types of a field in both revisions, walking through
+// objects and collections of the same kind
+func (c *moduleComparer) compareType(path string, from, to cty.Type, fromDefaults, toDefaults *typeexpr.Defaults, fromField, toField *ObjectField) {
+	switch {
+	case from.IsObjectType() && to.IsObjectType():
+		for _, name := range unionKeys(from.AttributeTypes(), to.AttributeTypes()) {
+			attributePath := path + "." + name
+
+			switch {
+			case !to.HasAttribute(name):
+				c.add(ChangeBreaking, ChangeFieldRemoved, attributePath, "the field was removed")
+			case !from.HasAttribute(name) && to.AttributeOptional(name):
+				c.add(ChangeMinor, ChangeFieldAdded, attributePath, "an optional field was added")
+			case !from.HasAttribute(name):
+				c.add(ChangeBreaking, ChangeRequiredFieldAdded, attributePath, "a required field was added")
+			default:
+				c.compareOptionality(attributePath, from.AttributeOptional(name), to.AttributeOptional(name),
+					attributeDefault(fromDefaults, name), attributeDefault(toDefaults, name))
+				c.compareType(attributePath, from.AttributeType(name), to.AttributeType(name),
+					childDefaults(fromDefaults, name), childDefaults(toDefaults, name), fromField.child(name), toField.child(name))
+			}
+		}
+
+	// Collections are walked through to the attributes of their objects only,
+	// other types are compared whole so that changes mention their collections
+	case sameCollectionKind(from, to) && containsObject(from.ElementType()) && containsObject(to.ElementType()):
+		c.compareType(path, from.ElementType(), to.ElementType(), childDefaults(fromDefaults, ""), childDefaults(toDefaults, ""), fromField, toField)
+
+	case !from.Equals(to):
+		c.add(ChangeBreaking, ChangeTypeChanged, path, "the type changed from "+typeexpr.TypeString(from)+" to "+typeexpr.TypeString(to))
+
+	default:
+		c.compareEnums(path, enumChoices(from, fromField), enumChoices(to, toField))
+	}
+}
+
+// compareEnums compares the `@enum` values of a field
+func (c *moduleComparer) compareEnums(path string, from, to []string) {
+	if len(to) == 0 {
+		return
+	}
+
+	if len(from) == 0 {
+		c.add(ChangeBreaking, ChangeEnumAdded, path, "the values are now restricted to "+strings.Join(to, ", "))
+		return
+	}
+
+	var removed, added []string
+
+	for _, choice := range from {
+		if !slices.Contains(to, choice) {
+			removed = append(removed, choice)
+		}
+	}
+
+	for _, choice := range to {
+		if !slices.Contains(from, choice) {
+			added = append(added, choice)
+		}
+	}
+
+	if len(removed) > 0 {
+		c.add(ChangeBreaking, ChangeEnumValueRemoved, path, "the @enum values "+strings.Join(removed, ", ")+" were removed")
+	}
+
+	if len(added) > 0 {
+		c.add(ChangeMinor, ChangeEnumValueAdded, path, "the @enum values "+strings.Join(added, ", ")+" were added")
+	}
+}
+
+func sameCollectionKind(a, b cty.Type) bool {
+	return a.IsListType() && b.IsListType() || a.IsSetType() && b.IsSetType() || a.IsMapType() && b.IsMapType()
+}
+
+func inputsByName(inputs []*terraform.Input) map[string]*terraform.Input {
+	byName := make(map[string]*terraform.Input, len(inputs))
+	for _, input := range inputs {
+		byName[input.Name] = input
+	}
+
+	return byName
+}
+
+// unionKeys returns the keys of both maps, sorted
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := slices.Collect(maps.Keys(a))
+
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+// attributeDefault returns the default value of an optional attribute, null
+// when it has none
+func attributeDefault(defaults *typeexpr.Defaults, name string) cty.Value {
+	if defaults != nil {
+		if value, ok := defaults.DefaultValues[name]; ok {
+			return value
+		}
+	}
+
+	return cty.NullVal(cty.DynamicPseudoType)
+}
+
+// defaultJSON returns a default value as JSON, the form terraform-docs reports
+// defaults in
+func defaultJSON(value cty.Value) string {
+	if value == cty.NilVal || value.IsNull() {
+		return "null"
+	}
+
+	content, err := ctyjson.Marshal(value, value.Type())
+	if err != nil {
+		return value.GoString()
+	}
+
+	return string(content)
+}
@@ -0,0 +1,39 @@
+package tfdocextras
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestCompareModules(t *testing.T) {
+	from := loadTestModule(t, "changes/v1")
+	to := loadTestModule(t, "changes/v2")
+
+	expected := []ModuleChange{
+		{Severity: ChangeBreaking, Code: ChangeFieldRemoved, Path: "legacy_mode", Message: "the variable was removed"},
+		{Severity: ChangeMinor, Code: ChangeFieldAdded, Path: "log_retention", Message: "an optional variable was added"},
+		{Severity: ChangeBreaking, Code: ChangeRequiredFieldAdded, Path: "network_id", Message: "a required variable was added"},
+		{Severity: ChangeMinor, Code: ChangeFieldAdded, Path: "node_pool.capacity", Message: "an optional field was added"},
+		{Severity: ChangeBreaking, Code: ChangeOptionalToRequired, Path: "node_pool.count", Message: "the field is now required"},
+		{Severity: ChangeMinor, Code: ChangeRequiredToOptional, Path: "node_pool.labels", Message: "the field is now optional"},
+		{Severity: ChangeBreaking, Code: ChangeEnumValueRemoved, Path: "node_pool.size", Message: `the @enum values "small" were removed`},
+		{Severity: ChangeMinor, Code: ChangeEnumValueAdded, Path: "node_pool.size", Message: `the @enum values "xlarge" were added`},
+		{Severity: ChangeBreaking, Code: ChangeEnumAdded, Path: "node_pool.taints.effect", Message: `the values are now restricted to "NoSchedule", "NoExecute"`},
+		{Severity: ChangeMinor, Code: ChangeFieldAdded, Path: "node_pool.taints.value", Message: "an optional field was added"},
+		{Severity: ChangeBreaking, Code: ChangeDefaultChanged, Path: "region", Message: `the default changed from "us-east-1" to "eu-west-1"`},
+		{Severity: ChangeBreaking, Code: ChangeTypeChanged, Path: "subnet_ids", Message: "the type changed from list(string) to set(string)"},
+		{Severity: ChangeBreaking, Code: ChangeTypeChanged, Path: "tags", Message: "the type changed from map(string) to map(number)"},
+		{Severity: ChangeMinor, Code: ChangeOutputAdded, Path: "output.arn", Message: "an output was added"},
+		{Severity: ChangeBreaking, Code: ChangeOutputRemoved, Path: "output.endpoint", Message: "the output was removed"},
+	}
+
+	changes := CompareModules(from, to)
+	if diff := deep.Equal(changes, expected); diff != nil {
+		t.Errorf("Changes mismatch:\n%v", diff)
+	}
+
+	if !HasBreakingChanges(changes) {
+		t.Error("Expected breaking changes")
+	}
+}
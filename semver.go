@@ -0,0 +1,127 @@
+package tfdocextras
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var semverRe = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// Version is a semantic version as described by https://semver.org; a leading
+// `v` (e.g. `v1.2.0`) is accepted when parsing.
+type Version struct {
+	Major      int    `json:"major"`
+	Minor      int    `json:"minor"`
+	Patch      int    `json:"patch"`
+	Prerelease string `json:"prerelease,omitempty"`
+	Build      string `json:"build,omitempty"`
+}
+
+// ParseVersion parses a semantic version string such as `1.2.0` or `v2.0.0-rc.1`
+func ParseVersion(str string) (Version, error) {
+	matches := semverRe.FindStringSubmatch(strings.TrimSpace(str))
+	if matches == nil {
+		return Version{}, fmt.Errorf("%q is not a valid semantic version", str)
+	}
+
+	// The regex guarantees these are valid integers; only overflow can fail here
+	var numbers [3]int
+	for i := range numbers {
+		n, err := strconv.Atoi(matches[i+1])
+		if err != nil {
+			return Version{}, fmt.Errorf("%q is not a valid semantic version: %w", str, err)
+		}
+
+		numbers[i] = n
+	}
+
+	return Version{
+		Major:      numbers[0],
+		Minor:      numbers[1],
+		Patch:      numbers[2],
+		Prerelease: matches[4],
+		Build:      matches[5],
+	}, nil
+}
+
+func (v Version) String() string {
+	str := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+
+	if v.Prerelease != "" {
+		str += "-" + v.Prerelease
+	}
+
+	if v.Build != "" {
+		str += "+" + v.Build
+	}
+
+	return str
+}
+
+// Compare returns -1, 0, or 1 depending on whether v has a lower, equal, or
+// higher precedence than other. Build metadata is ignored, as required by the
+// specification.
+func (v Version) Compare(other Version) int {
+	for _, pair := range [][2]int{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if c := compareInts(pair[0], pair[1]); c != 0 {
+			return c
+		}
+	}
+
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// comparePrerelease compares two dot-separated pre-release strings; a version
+// without a pre-release has a higher precedence than one with it.
+func comparePrerelease(a, b string) int {
+	if a == b {
+		return 0
+	}
+	if a == "" {
+		return 1
+	}
+	if b == "" {
+		return -1
+	}
+
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		aNum, aErr := strconv.Atoi(aParts[i])
+		bNum, bErr := strconv.Atoi(bParts[i])
+
+		switch {
+		case aErr == nil && bErr == nil:
+			if c := compareInts(aNum, bNum); c != 0 {
+				return c
+			}
+		case aErr == nil:
+			// Numeric identifiers have a lower precedence than alphanumeric ones
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(aParts[i], bParts[i]); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return compareInts(len(aParts), len(bParts))
+}
@@ -0,0 +1,69 @@
+package tfdocextras
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestParseVersion_Valid(t *testing.T) {
+	tests := map[string]Version{
+		"1.0.0":                {Major: 1, Minor: 0, Patch: 0},
+		"v2.10.3":              {Major: 2, Minor: 10, Patch: 3},
+		"1.2.0-rc.1":           {Major: 1, Minor: 2, Patch: 0, Prerelease: "rc.1"},
+		"1.2.0-beta+build.123": {Major: 1, Minor: 2, Patch: 0, Prerelease: "beta", Build: "build.123"},
+	}
+
+	for input, expected := range tests {
+		actual, err := ParseVersion(input)
+		if err != nil {
+			t.Errorf("ParseVersion(%q) failed: %v", input, err)
+			continue
+		}
+
+		if diff := deep.Equal(expected, actual); diff != nil {
+			t.Errorf("ParseVersion(%q) mismatch:\n%v", input, diff)
+		}
+	}
+}
+
+func TestParseVersion_Invalid(t *testing.T) {
+	for _, input := range []string{"", "1", "1.0", "v1.O", "1.0.0.0", "01.0.0", "1.0.0-", "latest"} {
+		if _, err := ParseVersion(input); err == nil {
+			t.Errorf("Expected ParseVersion(%q) to fail", input)
+		}
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	// Ordered from lowest to highest precedence
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"2.0.0",
+	}
+
+	for i := 0; i < len(ordered)-1; i++ {
+		lower, _ := ParseVersion(ordered[i])
+		higher, _ := ParseVersion(ordered[i+1])
+
+		if lower.Compare(higher) != -1 || higher.Compare(lower) != 1 {
+			t.Errorf("Expected %s < %s", ordered[i], ordered[i+1])
+		}
+	}
+
+	a, _ := ParseVersion("v1.0.0+build.1")
+	b, _ := ParseVersion("1.0.0+build.2")
+
+	if a.Compare(b) != 0 {
+		t.Errorf("Expected build metadata to be ignored")
+	}
+}
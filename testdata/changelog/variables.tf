@@ -0,0 +1,46 @@
+variable "name" {
+  type        = string
+  description = <<EOT
+    The name of the cluster.
+
+    @since 1.0.0
+  EOT
+}
+
+variable "node_pool" {
+  type = object({
+    /// The instance type of the nodes
+    instance_type = string
+
+    /// The number of nodes
+    ///
+    /// @deprecated v2.1.0 Use size instead
+    count = optional(number)
+
+    /// The size of the pool
+    ///
+    /// @since 2.1.0
+    size = optional(string)
+
+    /// Legacy settings
+    ///
+    /// @deprecated Use the new settings instead
+    legacy = optional(string)
+  })
+  description = <<EOT
+    The default node pool.
+
+    @since v1.1.0
+  EOT
+}
+
+variable "tags" {
+  type        = map(string)
+  description = <<EOT
+    The tags of the resources.
+
+    @since 2.1.0
+    @deprecated 3.0.0
+  EOT
+  default     = {}
+}
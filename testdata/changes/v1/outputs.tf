@@ -0,0 +1,9 @@
+output "id" {
+  description = "The ID of the cluster"
+  value       = "id"
+}
+
+output "endpoint" {
+  description = "The endpoint of the cluster"
+  value       = "endpoint"
+}
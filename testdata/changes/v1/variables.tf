@@ -0,0 +1,44 @@
+variable "name" {
+  type        = string
+  description = "The name of the cluster"
+}
+
+variable "region" {
+  type        = string
+  description = "The region of the cluster"
+  default     = "us-east-1"
+}
+
+variable "legacy_mode" {
+  type        = bool
+  description = "Enables the legacy mode"
+  default     = false
+}
+
+variable "node_pool" {
+  type = object({
+    /// @enum small|medium|large
+    size = optional(string, "medium")
+
+    count = optional(number, 3)
+
+    labels = map(string)
+
+    taints = optional(list(object({
+      key    = string
+      effect = string
+    })), [])
+  })
+  description = "The default node pool"
+}
+
+variable "tags" {
+  type        = map(string)
+  description = "The tags of the resources"
+  default     = {}
+}
+
+variable "subnet_ids" {
+  type        = list(string)
+  description = "The subnets of the cluster"
+}
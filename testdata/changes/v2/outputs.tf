@@ -0,0 +1,9 @@
+output "id" {
+  description = "The ID of the cluster"
+  value       = "id"
+}
+
+output "arn" {
+  description = "The ARN of the cluster"
+  value       = "arn"
+}
@@ -0,0 +1,56 @@
+variable "name" {
+  type        = string
+  description = "The name of the cluster"
+}
+
+variable "region" {
+  type        = string
+  description = "The region of the cluster"
+  default     = "eu-west-1"
+}
+
+variable "network_id" {
+  type        = string
+  description = "The network of the cluster"
+}
+
+variable "node_pool" {
+  type = object({
+    /// @enum medium|large|xlarge
+    size = optional(string, "medium")
+
+    count = number
+
+    labels = optional(map(string))
+
+    taints = optional(list(object({
+      key = string
+
+      /// @enum NoSchedule|NoExecute
+      effect = string
+
+      value = optional(string)
+    })), [])
+
+    /// @enum standard|spot
+    capacity = optional(string, "standard")
+  })
+  description = "The default node pool"
+}
+
+variable "tags" {
+  type        = map(number)
+  description = "The tags of the resources"
+  default     = {}
+}
+
+variable "log_retention" {
+  type        = list(number)
+  description = "The retention of the logs"
+  default     = []
+}
+
+variable "subnet_ids" {
+  type        = set(string)
+  description = "The subnets of the cluster"
+}
@@ -0,0 +1,9 @@
+variable "alarm_condition" {
+  type        = string
+  description = <<EOT
+    The condition that triggers the alarm.
+
+    @regex /^(Average|Minimum|Maximum) (<=|<|>=|>) \d+$/ "Average >= 20" "Minimum < 10" description="Statistic, operator, and threshold"
+    @regex /^maximum/i "Maximum <= 100" "Average > 5"
+  EOT
+}
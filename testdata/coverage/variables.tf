@@ -0,0 +1,31 @@
+variable "name" {
+  type        = string
+  description = "The name of the file system"
+}
+
+variable "tags" {
+  type = map(string)
+}
+
+variable "lifecycle_policy" {
+  type = object({
+    /// Transition files to infrequent access storage
+    transition_to_ia = optional(string)
+
+    transition_to_archive = optional(string)
+
+    /// @since 1.2.0
+    archive = optional(object({
+      /// The storage class of archived files
+      storage_class = string
+
+      retention_days = optional(number)
+    }))
+  })
+  description = <<EOT
+    Configures the lifecycle policy.
+
+    @since 1.0.0
+  EOT
+  default     = null
+}
@@ -0,0 +1,57 @@
+variable "name" {
+  type        = string
+  description = <<EOT
+    The name of the cluster.
+    Used as a prefix for every resource.
+
+    @regex /^[a-z][a-z0-9-]*$/ "my-cluster"
+  EOT
+}
+
+variable "node_pool" {
+  type = object({
+    /// The instance type of the nodes
+    instance_type = string
+
+    /// The number of nodes
+    /// @enum 1|3|5
+    size = optional(number, 3)
+
+    /// The labels of the nodes
+    labels = optional(map(string))
+
+    /// @deprecated Use size instead
+    count = optional(number)
+  })
+  description = "The default node pool"
+}
+
+variable "listeners" {
+  type = map(object({
+    /// The port to listen on
+    port = number
+
+    /// @enum HTTP|HTTPS
+    protocol = optional(string, "HTTPS")
+  }))
+  description = <<EOT
+    The listeners of the load balancer.
+
+    @key "The name of the listener" /^[a-z]+$/ "web"
+  EOT
+  default     = {}
+}
+
+variable "tags" {
+  type        = map(string)
+  description = "The tags of the resources"
+  default = {
+    Team = "platform"
+  }
+}
+
+variable "debug" {
+  type        = bool
+  description = "@internal"
+  default     = false
+}
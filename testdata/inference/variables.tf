@@ -0,0 +1,80 @@
+variable "environment" {
+  type        = string
+  description = "The environment of the deployment"
+
+  validation {
+    condition     = contains(["dev", "staging", "prod"], var.environment)
+    error_message = "The environment must be dev, staging, or prod."
+  }
+}
+
+variable "bucket_name" {
+  type        = string
+  description = "The name of the bucket"
+
+  validation {
+    condition     = can(regex("^[a-z0-9.-]{3,63}$", var.bucket_name)) && length(var.bucket_name) > 3
+    error_message = <<EOT
+      The bucket name is invalid.
+    EOT
+  }
+}
+
+variable "zone" {
+  type        = string
+  description = "The availability zone"
+  default     = null
+
+  validation {
+    condition     = var.zone == null || length(regexall("^[a-z]{2}-[a-z]+-[0-9][a-z]$", var.zone)) > 0
+    error_message = "The zone ${var.zone} is invalid."
+  }
+}
+
+variable "tier" {
+  type        = string
+  description = <<EOT
+    The storage tier.
+
+    @enum basic|premium
+  EOT
+  default     = "gold"
+
+  validation {
+    condition     = contains(["basic", "premium", "gold"], var.tier)
+    error_message = "The tier is invalid."
+  }
+}
+
+variable "rules" {
+  type = list(object({
+    /// The action of the rule
+    action = string
+
+    /// The ports the rule applies to
+    ports = optional(list(number), [])
+  }))
+  description = "The firewall rules"
+  default     = []
+
+  validation {
+    condition     = alltrue([for rule in var.rules : contains(["allow", "deny"], rule.action)])
+    error_message = "The action of a rule must be allow or deny."
+  }
+
+  validation {
+    condition     = alltrue(flatten([for rule in var.rules : [for port in rule.ports : contains([80, 443], port)]]))
+    error_message = "Only ports 80 and 443 are supported."
+  }
+}
+
+variable "tags" {
+  type        = map(string)
+  description = "The tags of the resources"
+  default     = {}
+
+  validation {
+    condition     = alltrue([for key, value in var.tags : can(regex("^[A-Z]", key)) && contains(local.tag_values, value)])
+    error_message = "Tag keys must be capitalized."
+  }
+}
@@ -0,0 +1,27 @@
+variable "access_points" {
+  type = map(object({
+    /// Owner group ID for the access point's root directory
+    owner_group_id = number
+
+    /// Configures the permissions for the root directory
+    ///
+    /// @since 2.1.0
+    /// @stability beta
+    permissions = optional(object({
+      /// The POSIX permissions to apply
+      mode = string
+
+      /// The owner of the root directory
+      ///
+      /// @deprecated Use `owner_group_id` instead
+      owner = optional(string)
+    }))
+  }))
+  description = <<EOT
+    Configures access points.
+
+    @since 2.0.0
+    @example "Access Points" #access-points
+  EOT
+  default     = {}
+}
@@ -0,0 +1,66 @@
+variable "undocumented" {
+  type = string
+}
+
+variable "throughput_mode" {
+  type        = string
+  description = <<EOT
+    The throughput mode, see [throughput modes][throughput].
+
+    @enum bursting|provisioned
+    @since 1.0.0
+    @since 1.1.0
+    @todo document elastic mode
+  EOT
+  default     = "elastic"
+}
+
+variable "performance_mode" {
+  type        = string
+  description = <<EOT
+    The performance mode, see [performance modes][performance].
+
+    @enum generalPurpose|maxIO
+    @link {performance} https://docs.aws.amazon.com/efs/latest/ug/performance.html
+  EOT
+  default     = "generalPurpose"
+}
+
+variable "lifecycle_policy" {
+  type = object({
+    transition_to_ia = optional(string)
+
+    /// Transition files to archive storage
+    ///
+    /// @enum AFTER_1_DAY|AFTER_7_DAYS
+    transition_to_archive = optional(string, "AFTER_30_DAYS")
+
+    /// @lint-ignore undocumented
+    transition_to_primary_storage_class = optional(string)
+  })
+  description = "Configures the lifecycle policy."
+  default     = null
+}
+
+variable "replication" {
+  type = object({
+    region = optional(string)
+  })
+  description = <<EOT
+    Configures replication.
+
+    @lint-ignore all
+    @lint-ignore nonexistent-rule
+  EOT
+  default     = null
+}
+
+variable "quote_style" {
+  type        = string
+  description = <<EOT
+    The quote style, whose escaped values are valid defaults.
+
+    @enum "it\"s" | 'a|b' | plain
+  EOT
+  default     = "it\"s"
+}
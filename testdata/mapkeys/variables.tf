@@ -0,0 +1,19 @@
+variable "access_points" {
+  type = map(object({
+    /// Tags
+    ///
+    /// @key "Tag name" /^[A-Za-z]+$/ Name Environment
+    tags = optional(map(string), {})
+
+    /// Not a map
+    ///
+    /// @key Wrong
+    path = string
+  }))
+  description = <<EOT
+    Configures access points.
+
+    @key "The name of the access point" /^[a-z-]+$/ "my-ap" "fs-ap"
+  EOT
+  default = {}
+}
@@ -0,0 +1,17 @@
+output "file_system_id" {
+  description = <<EOT
+    The ID of the file system.
+
+    @since 1.0.0
+  EOT
+  value       = "fs-12345678"
+}
+
+output "mount_target_ips" {
+  description = <<EOT
+    The IP addresses of the mount targets
+
+    @link {mount-targets} https://docs.aws.amazon.com/efs/latest/ug/accessing-fs.html
+  EOT
+  value       = ["10.0.0.1"]
+}
@@ -0,0 +1,5 @@
+variable "mount_targets" {
+  type        = list(string)
+  description = "The subnets to create [mount targets][mount-targets] in"
+  default     = []
+}
@@ -0,0 +1,50 @@
+variable "name" {
+  type        = string
+  description = <<EOT
+    The name of the file system.
+
+    @regex /^[a-z][a-z0-9-]*$/
+  EOT
+}
+
+variable "throughput_mode" {
+  type        = string
+  description = <<EOT
+    The throughput mode.
+
+    @enum bursting|elastic|provisioned
+    @deprecated Use `throughput` instead
+  EOT
+  default     = "bursting"
+}
+
+variable "access_points" {
+  type = map(object({
+    /// The POSIX user of the access point
+    user = object({
+      uid = number
+      gid = number
+    })
+
+    /// @enum 0755|0750
+    permissions = optional(string, "0755")
+
+    secondary_gids = optional(set(number), [])
+  }))
+  description = <<EOT
+    Configures access points.
+
+    @key "The name of the access point" /^[a-z-]+$/
+  EOT
+  default     = {}
+}
+
+variable "retention" {
+  type    = tuple([number, string])
+  default = null
+}
+
+variable "extra" {
+  description = "Any value"
+  default     = null
+}
@@ -0,0 +1,43 @@
+variable "name" {
+  type        = string
+  description = <<EOT
+    The name of the file system.
+
+    @regex /^[a-z][a-z0-9-]*$/ "my-file-system"
+  EOT
+}
+
+variable "performance_mode" {
+  type        = string
+  description = <<EOT
+    The performance mode.
+
+    @enum generalPurpose|maxIO
+  EOT
+  default     = "generalPurpose"
+}
+
+variable "access_points" {
+  type = map(object({
+    /// The POSIX user of the access point
+    user = object({
+      uid = number
+      gid = number
+    })
+
+    /// @enum 0755|0750
+    permissions = optional(string, "0755")
+  }))
+  description = <<EOT
+    Configures access points.
+
+    @key "The name of the access point" /^[a-z-]+$/
+  EOT
+  default     = {}
+}
+
+variable "subnet_ids" {
+  type        = list(string)
+  description = "The subnets of the mount targets"
+  default     = []
+}
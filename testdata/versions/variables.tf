@@ -0,0 +1,36 @@
+variable "name" {
+  type        = string
+  description = <<EOT
+    The name of the file system.
+
+    @since v1.O
+  EOT
+}
+
+variable "lifecycle_policy" {
+  type = object({
+    /// Transition files to infrequent access
+    ///
+    /// @since 1.0.0
+    transition_to_ia = optional(string)
+
+    /// Transition files to archive storage
+    transition_to_archive = optional(string)
+  })
+  description = <<EOT
+    Configures the lifecycle policy.
+
+    @since 1.2.0
+  EOT
+  default     = null
+}
+
+variable "throughput_mode" {
+  type        = string
+  description = <<EOT
+    The throughput mode for the file system.
+
+    @since v1.2.0
+  EOT
+  default     = "bursting"
+}
@@ -0,0 +1,216 @@
+package tfdocextras
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/terraform-docs/terraform-docs/terraform"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// VariableValidation is a Terraform `validation` block of a variable enforcing
+// a directive of the variable or of one of its nested fields
+type VariableValidation struct {
+	Variable     string `json:"variable"`
+	Condition    string `json:"condition"`
+	ErrorMessage string `json:"error_message"`
+}
+
+// Block returns the HCL source of the validation block
+func (v VariableValidation) Block() string {
+	return "validation {\n  condition     = " + v.Condition + "\n  error_message = " + hclString(v.ErrorMessage) + "\n}"
+}
+
+// accessStep is a step from a variable to one of its nested values: either the
+// attribute called attr of an object, or every element of a collection
+type accessStep struct {
+	attr string
+}
+
+var eachElement = accessStep{}
+
+// validationGenerator collects the validations of a variable
+type validationGenerator struct {
+	variable    string
+	validations []VariableValidation
+}
+
+// GenerateValidations returns the validation blocks enforcing the `@enum`,
+// `@regex`, and `@key` directives of the inputs of a module loaded by
+// terraform-docs and of their nested fields, iterating over the elements of
+// maps, lists, and sets. Null values, such as unset optional attributes, are
+// always valid.
+func GenerateValidations(inputs []*terraform.Input) []VariableValidation {
+	var validations []VariableValidation
+
+	for _, input := range inputs {
+		ty, _ := inputType(input)
+		field := inputField(input)
+
+		generator := validationGenerator{variable: input.Name}
+		generator.walkField(ty, &field, nil, "var."+input.Name)
+
+		validations = append(validations, generator.validations...)
+	}
+
+	return validations
+}
+
+// walkField adds the validations of the directives of a variable or attribute
+// at the end of steps, then of its nested fields
+func (g *validationGenerator) walkField(ty cty.Type, field *ObjectField, steps []accessStep, path string) {
+	if field != nil {
+		g.addDirectives(ty, field, steps, path)
+	}
+
+	g.walkType(ty, field, steps, path)
+}
+
+// walkType walks through collections to the attributes of objects
+func (g *validationGenerator) walkType(ty cty.Type, field *ObjectField, steps []accessStep, path string) {
+	switch {
+	case ty.IsObjectType():
+		for _, name := range slices.Sorted(maps.Keys(ty.AttributeTypes())) {
+			attrSteps := append(slices.Clip(steps), accessStep{attr: name})
+			g.walkField(ty.AttributeType(name), field.child(name), attrSteps, path+"."+name)
+		}
+	case ty.IsListType() || ty.IsSetType() || ty.IsMapType():
+		g.walkType(ty.ElementType(), field, append(slices.Clip(steps), eachElement), path+"[*]")
+	}
+}
+
+func (g *validationGenerator) addDirectives(ty cty.Type, field *ObjectField, steps []accessStep, path string) {
+	// `@enum` and `@regex` apply to the primitive elements of collections
+	leafType, leafSteps, leafPath := ty, steps, path
+	for leafType.IsListType() || leafType.IsSetType() || leafType.IsMapType() {
+		leafType = leafType.ElementType()
+		leafSteps = append(slices.Clip(leafSteps), eachElement)
+		leafPath += "[*]"
+	}
+
+	for _, attr := range field.Documentation.Directives {
+		if (attr.Parsed.Flags & IsValid) == 0 {
+			continue
+		}
+
+		switch attr.Parsed.Type {
+		case DirEnum:
+			if !leafType.IsPrimitiveType() {
+				continue
+			}
+
+			literals := enumValues(attr.Parsed.Args)
+			for i, choice := range literals {
+				literals[i] = primitiveLiteral(leafType, choice)
+			}
+
+			g.add(leafSteps, func(value string) string {
+				return "contains([" + strings.Join(literals, ", ") + "], " + value + ")"
+			}, "The value of "+leafPath+" must be one of "+strings.Join(literals, ", ")+".")
+
+		case DirRegex:
+			if !leafType.Equals(cty.String) {
+				continue
+			}
+
+			pattern := attr.Parsed.Args[0]
+			message := "The value of " + leafPath + " must match /" + pattern + "/."
+			if description := attr.Parsed.NamedArgs["description"]; description != "" {
+				message += " " + sentence(description)
+			}
+
+			g.add(leafSteps, func(value string) string {
+				return "can(regex(" + hclString(pattern) + ", " + value + "))"
+			}, message)
+		}
+	}
+
+	if field.MapKey != nil && field.MapKey.Pattern != "" && ty.IsMapType() {
+		pattern := field.MapKey.Pattern
+
+		g.add(steps, func(value string) string {
+			return "alltrue([for key in keys(" + value + ") : can(regex(" + hclString(pattern) + ", key))])"
+		}, "The keys of "+path+" must match /"+pattern+"/.")
+	}
+}
+
+// add records a validation checking every value reached by steps with the
+// condition returned by check
+func (g *validationGenerator) add(steps []accessStep, check func(value string) string, message string) {
+	g.validations = append(g.validations, VariableValidation{
+		Variable:     g.variable,
+		Condition:    validationCondition("var."+g.variable, steps, check, 0),
+		ErrorMessage: message,
+	})
+}
+
+// validationCondition builds the expression checking the values reached from
+// root by steps. Terraform evaluates both operands of `||`, so null values are
+// skipped with conditional expressions, whose other branch is not evaluated.
+func validationCondition(root string, steps []accessStep, check func(value string) string, depth int) string {
+	expr := root
+	guard := expr + " == null"
+
+	// Attributes of null objects cannot be accessed
+	if len(steps) > 0 && steps[0] != eachElement {
+		for len(steps) > 0 && steps[0] != eachElement {
+			expr += "." + steps[0].attr
+			steps = steps[1:]
+		}
+
+		guard = "try(" + expr + ", null) == null"
+	}
+
+	if len(steps) == 0 {
+		return guard + " ? true : " + check(expr)
+	}
+
+	element := "v"
+	if depth > 0 {
+		element += strconv.Itoa(depth + 1)
+	}
+
+	return guard + " ? true : alltrue([for " + element + " in " + expr + " : " + validationCondition(element, steps[1:], check, depth+1) + "])"
+}
+
+// primitiveLiteral returns the HCL literal of an `@enum` value of type ty
+func primitiveLiteral(ty cty.Type, value string) string {
+	switch {
+	case ty.Equals(cty.Number):
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return value
+		}
+	case ty.Equals(cty.Bool):
+		if _, err := strconv.ParseBool(value); err == nil {
+			return value
+		}
+	}
+
+	return hclString(value)
+}
+
+// hclString quotes str as an HCL string literal, escaping template sequences
+func hclString(str string) string {
+	quoted := strconv.Quote(str)
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+
+	return strings.ReplaceAll(quoted, "%{", "%%{")
+}
+
+// sentence capitalizes str and ends it with a period, as Terraform expects of
+// error messages
+func sentence(str string) string {
+	str = strings.TrimSpace(str)
+	if str == "" {
+		return str
+	}
+
+	str = strings.ToUpper(str[:1]) + str[1:]
+	if !strings.HasSuffix(str, ".") && !strings.HasSuffix(str, "?") {
+		str += "."
+	}
+
+	return str
+}
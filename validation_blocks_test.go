@@ -0,0 +1,160 @@
+package tfdocextras
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/tryfunc"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+// alltrueFunc mimics the alltrue function of Terraform
+var alltrueFunc = function.New(&function.Spec{
+	Params: []function.Parameter{{Name: "list", Type: cty.List(cty.Bool)}},
+	Type:   function.StaticReturnType(cty.Bool),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		for it := args[0].ElementIterator(); it.Next(); {
+			if _, element := it.Element(); element.False() {
+				return cty.False, nil
+			}
+		}
+
+		return cty.True, nil
+	},
+})
+
+// failedValidations evaluates the validations the way Terraform would against
+// the variables set in tfvars, and returns the error messages of those failing
+func failedValidations(t *testing.T, validations []VariableValidation, tfvars string) []string {
+	t.Helper()
+
+	module := loadTestModule(t, "values")
+
+	file, diags := hclsyntax.ParseConfig([]byte(tfvars), "test.tfvars", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	attributes, _ := file.Body.JustAttributes()
+	variables := map[string]cty.Value{}
+
+	for _, input := range module.Inputs {
+		ty, defaults := inputType(input)
+		variables[input.Name] = cty.NullVal(ty)
+
+		if attribute, ok := attributes[input.Name]; ok {
+			value, _ := attribute.Expr.Value(nil)
+			if defaults != nil {
+				value = defaults.Apply(value)
+			}
+
+			converted, err := convert.Convert(value, ty)
+			if err != nil {
+				t.Fatalf("Invalid value of %s: %v", input.Name, err)
+			}
+
+			variables[input.Name] = converted
+		}
+	}
+
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{"var": cty.ObjectVal(variables)},
+		Functions: map[string]function.Function{
+			"alltrue":  alltrueFunc,
+			"can":      tryfunc.CanFunc,
+			"contains": stdlib.ContainsFunc,
+			"keys":     stdlib.KeysFunc,
+			"regex":    stdlib.RegexFunc,
+			"try":      tryfunc.TryFunc,
+		},
+	}
+
+	var failed []string
+	for _, validation := range validations {
+		expr, diags := hclsyntax.ParseExpression([]byte(validation.Condition), "condition", hcl.InitialPos)
+		if diags.HasErrors() {
+			t.Fatalf("Invalid condition %s: %v", validation.Condition, diags)
+		}
+
+		result, diags := expr.Value(ctx)
+		if diags.HasErrors() {
+			t.Fatalf("Failed to evaluate %s: %v", validation.Condition, diags)
+		}
+
+		if result.False() {
+			failed = append(failed, validation.ErrorMessage)
+		}
+	}
+
+	return failed
+}
+
+func TestGenerateValidations(t *testing.T) {
+	module := loadTestModule(t, "values")
+	validations := GenerateValidations(module.Inputs)
+
+	expected := []VariableValidation{
+		{
+			Variable:     "access_points",
+			Condition:    `var.access_points == null ? true : alltrue([for key in keys(var.access_points) : can(regex("^[a-z-]+$", key))])`,
+			ErrorMessage: "The keys of var.access_points must match /^[a-z-]+$/.",
+		},
+		{
+			Variable:     "access_points",
+			Condition:    `var.access_points == null ? true : alltrue([for v in var.access_points : try(v.permissions, null) == null ? true : contains(["0755", "0750"], v.permissions)])`,
+			ErrorMessage: `The value of var.access_points[*].permissions must be one of "0755", "0750".`,
+		},
+		{
+			Variable:     "name",
+			Condition:    `var.name == null ? true : can(regex("^[a-z][a-z0-9-]*$", var.name))`,
+			ErrorMessage: "The value of var.name must match /^[a-z][a-z0-9-]*$/.",
+		},
+		{
+			Variable:     "performance_mode",
+			Condition:    `var.performance_mode == null ? true : contains(["generalPurpose", "maxIO"], var.performance_mode)`,
+			ErrorMessage: `The value of var.performance_mode must be one of "generalPurpose", "maxIO".`,
+		},
+	}
+
+	if diff := deep.Equal(validations, expected); diff != nil {
+		t.Errorf("Validations mismatch:\n%v", diff)
+	}
+}
+
+func TestGenerateValidations_Evaluation(t *testing.T) {
+	module := loadTestModule(t, "values")
+	validations := GenerateValidations(module.Inputs)
+
+	valid := `
+name = "my-file-system"
+access_points = {
+  shared = { user = { uid = 1000, gid = 1000 } }
+}
+`
+	if failed := failedValidations(t, validations, valid); len(failed) != 0 {
+		t.Errorf("Expected valid values to pass, got %v", failed)
+	}
+
+	invalid := `
+name             = "My File System"
+performance_mode = "bursting"
+access_points = {
+  Admin  = { user = { uid = 0, gid = 0 }, permissions = "0777" }
+}
+`
+	expected := []string{
+		"The keys of var.access_points must match /^[a-z-]+$/.",
+		`The value of var.access_points[*].permissions must be one of "0755", "0750".`,
+		"The value of var.name must match /^[a-z][a-z0-9-]*$/.",
+		`The value of var.performance_mode must be one of "generalPurpose", "maxIO".`,
+	}
+
+	if diff := deep.Equal(failedValidations(t, validations, invalid), expected); diff != nil {
+		t.Errorf("Failed validations mismatch:\n%v", diff)
+	}
+}
@@ -0,0 +1,333 @@
+package tfdocextras
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// InferredConstraint is an `@enum` or `@regex` constraint recognized in the
+// condition of a variable's validation block. Path is the variable or nested
+// field it applies to, such as "access_points.permissions".
+type InferredConstraint struct {
+	Path         string   `json:"path"`
+	Enumerations []string `json:"enumerations,omitempty"`
+	Pattern      string   `json:"pattern,omitempty"`
+}
+
+// constraintInferrer collects the constraints of the validation blocks of a
+// variable
+type constraintInferrer struct {
+	variable    string
+	constraints []InferredConstraint
+}
+
+// InferConstraints recognizes the constraints enforced by the validation blocks
+// of the variables declared in a Terraform configuration file. Conditions
+// checking a value with `contains([...], value)` are inferred as `@enum`, and
+// those checking it with `can(regex("...", value))` or
+// `length(regexall("...", value)) > 0` as `@regex`. Values are the variable,
+// its attributes, and the elements iterated by for expressions; null guards and
+// conditions combined with `&&` or `alltrue` are looked through. Other
+// conditions are ignored.
+func InferConstraints(filename string, content []byte) ([]InferredConstraint, error) {
+	blocks, err := variableBlocks(filename, content)
+	if err != nil {
+		return nil, err
+	}
+
+	var constraints []InferredConstraint
+
+	for _, block := range blocks {
+		inferrer := constraintInferrer{variable: block.Labels[0]}
+
+		for _, nested := range block.Body.Blocks {
+			if condition, ok := nested.Body.Attributes["condition"]; ok && nested.Type == "validation" {
+				inferrer.walk(condition.Expr, map[string]string{})
+			}
+		}
+
+		constraints = append(constraints, inferrer.constraints...)
+	}
+
+	return constraints, nil
+}
+
+// ParseValidations returns the validation blocks of the variables declared in a
+// Terraform configuration file, in order. Conditions are copied as written, and
+// error messages are evaluated unless they interpolate values, in which case
+// they are copied as written as well.
+func ParseValidations(filename string, content []byte) ([]VariableValidation, error) {
+	blocks, err := variableBlocks(filename, content)
+	if err != nil {
+		return nil, err
+	}
+
+	var validations []VariableValidation
+
+	for _, block := range blocks {
+		for _, nested := range block.Body.Blocks {
+			condition, ok := nested.Body.Attributes["condition"]
+			if !ok || nested.Type != "validation" {
+				continue
+			}
+
+			validation := VariableValidation{
+				Variable:  block.Labels[0],
+				Condition: string(condition.Expr.Range().SliceBytes(content)),
+			}
+
+			if message, ok := nested.Body.Attributes["error_message"]; ok {
+				validation.ErrorMessage = string(message.Expr.Range().SliceBytes(content))
+
+				if value, diags := message.Expr.Value(nil); !diags.HasErrors() && value.Type().Equals(cty.String) && value.IsKnown() && !value.IsNull() {
+					validation.ErrorMessage = strings.TrimSpace(value.AsString())
+				}
+			}
+
+			validations = append(validations, validation)
+		}
+	}
+
+	return validations, nil
+}
+
+// variableBlocks returns the variable blocks of a Terraform configuration file
+func variableBlocks(filename string, content []byte) ([]*hclsyntax.Block, error) {
+	file, diags := hclsyntax.ParseConfig(content, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	var blocks []*hclsyntax.Block
+
+	for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+		if block.Type == "variable" && len(block.Labels) == 1 {
+			blocks = append(blocks, block)
+		}
+	}
+
+	return blocks, nil
+}
+
+// walk looks for constraints in a condition, where bindings maps the symbols
+// of enclosing for expressions to the path of the values they iterate over
+func (c *constraintInferrer) walk(expr hclsyntax.Expression, bindings map[string]string) {
+	switch expr := expr.(type) {
+	case *hclsyntax.ParenthesesExpr:
+		c.walk(expr.Expression, bindings)
+
+	case *hclsyntax.ConditionalExpr:
+		// Null guards such as `var.x == null ? true : ...` accept null values
+		// only, other conditions may accept values the other branch rejects
+		if isNullCheck(expr.Condition) && isLiteral(expr.TrueResult, cty.True) {
+			c.walk(expr.FalseResult, bindings)
+		} else if isNotNullCheck(expr.Condition) && isLiteral(expr.FalseResult, cty.True) {
+			c.walk(expr.TrueResult, bindings)
+		}
+
+	case *hclsyntax.BinaryOpExpr:
+		switch expr.Op {
+		case hclsyntax.OpLogicalAnd:
+			c.walk(expr.LHS, bindings)
+			c.walk(expr.RHS, bindings)
+		case hclsyntax.OpLogicalOr:
+			if isNullCheck(expr.LHS) {
+				c.walk(expr.RHS, bindings)
+			} else if isNullCheck(expr.RHS) {
+				c.walk(expr.LHS, bindings)
+			}
+		case hclsyntax.OpGreaterThan:
+			// length(regexall("...", value)) > 0
+			length, ok := expr.LHS.(*hclsyntax.FunctionCallExpr)
+			if ok && length.Name == "length" && len(length.Args) == 1 && isLiteral(expr.RHS, cty.Zero) {
+				c.regex(length.Args[0], "regexall", bindings)
+			}
+		}
+
+	case *hclsyntax.TupleConsExpr:
+		for _, element := range expr.Exprs {
+			c.walk(element, bindings)
+		}
+
+	case *hclsyntax.ForExpr:
+		path, ok := c.resolve(unwrapCall(expr.CollExpr, "values"), bindings)
+		if !ok {
+			return
+		}
+
+		scoped := make(map[string]string, len(bindings)+1)
+		for symbol, bound := range bindings {
+			scoped[symbol] = bound
+		}
+
+		delete(scoped, expr.KeyVar)
+		scoped[expr.ValVar] = path
+
+		c.walk(expr.ValExpr, scoped)
+
+	case *hclsyntax.FunctionCallExpr:
+		switch {
+		case (expr.Name == "alltrue" || expr.Name == "flatten") && len(expr.Args) == 1:
+			c.walk(expr.Args[0], bindings)
+		case expr.Name == "can" && len(expr.Args) == 1:
+			c.regex(expr.Args[0], "regex", bindings)
+		case expr.Name == "contains" && len(expr.Args) == 2:
+			c.enum(expr.Args[0], expr.Args[1], bindings)
+		}
+	}
+}
+
+// enum records the values of list when it is a tuple of primitive literals
+func (c *constraintInferrer) enum(list, value hclsyntax.Expression, bindings map[string]string) {
+	tuple, ok := list.(*hclsyntax.TupleConsExpr)
+	if !ok || len(tuple.Exprs) == 0 {
+		return
+	}
+
+	path, ok := c.resolve(value, bindings)
+	if !ok {
+		return
+	}
+
+	choices := make([]string, 0, len(tuple.Exprs))
+	for _, element := range tuple.Exprs {
+		choice, ok := literalString(element)
+		if !ok {
+			return
+		}
+
+		choices = append(choices, choice)
+	}
+
+	c.constraints = append(c.constraints, InferredConstraint{Path: path, Enumerations: choices})
+}
+
+// regex records the pattern of expr when it calls function (regex or
+// regexall) with a literal pattern
+func (c *constraintInferrer) regex(expr hclsyntax.Expression, function string, bindings map[string]string) {
+	call, ok := expr.(*hclsyntax.FunctionCallExpr)
+	if !ok || call.Name != function || len(call.Args) != 2 {
+		return
+	}
+
+	pattern, ok := literalString(call.Args[0])
+	if !ok {
+		return
+	}
+
+	if path, ok := c.resolve(call.Args[1], bindings); ok {
+		c.constraints = append(c.constraints, InferredConstraint{Path: path, Pattern: pattern})
+	}
+}
+
+// resolve returns the path of the field a value comes from: the variable, one
+// of its attributes, or an element iterated by a for expression. Indexes are
+// ignored since constraints apply to every element of a collection.
+func (c *constraintInferrer) resolve(expr hclsyntax.Expression, bindings map[string]string) (string, bool) {
+	switch expr := expr.(type) {
+	case *hclsyntax.ParenthesesExpr:
+		return c.resolve(expr.Expression, bindings)
+
+	case *hclsyntax.FunctionCallExpr:
+		// try(value, null) guards the access to attributes of null objects
+		if expr.Name == "try" && len(expr.Args) > 0 {
+			return c.resolve(expr.Args[0], bindings)
+		}
+
+	case *hclsyntax.ScopeTraversalExpr:
+		traversal := expr.Traversal
+		path, ok := bindings[traversal.RootName()]
+
+		if traversal.RootName() == "var" {
+			if len(traversal) < 2 {
+				return "", false
+			}
+
+			if attr, isAttr := traversal[1].(hcl.TraverseAttr); !isAttr || attr.Name != c.variable {
+				return "", false
+			}
+
+			path, ok = c.variable, true
+			traversal = traversal[1:]
+		}
+
+		if !ok {
+			return "", false
+		}
+
+		for _, step := range traversal[1:] {
+			if attr, isAttr := step.(hcl.TraverseAttr); isAttr {
+				path += "." + attr.Name
+			}
+		}
+
+		return path, true
+	}
+
+	return "", false
+}
+
+// unwrapCall returns the argument of expr when it calls function with a single
+// argument, and expr otherwise
+func unwrapCall(expr hclsyntax.Expression, function string) hclsyntax.Expression {
+	if call, ok := expr.(*hclsyntax.FunctionCallExpr); ok && call.Name == function && len(call.Args) == 1 {
+		return call.Args[0]
+	}
+
+	return expr
+}
+
+// literalString returns the string form of a primitive literal
+func literalString(expr hclsyntax.Expression) (string, bool) {
+	if len(expr.Variables()) > 0 {
+		return "", false
+	}
+
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() || value.IsNull() || !value.IsKnown() || !value.Type().IsPrimitiveType() {
+		return "", false
+	}
+
+	str, err := convert.Convert(value, cty.String)
+	if err != nil {
+		return "", false
+	}
+
+	return str.AsString(), true
+}
+
+// isLiteral reports whether expr is the literal value
+func isLiteral(expr hclsyntax.Expression, value cty.Value) bool {
+	literal, ok := expr.(*hclsyntax.LiteralValueExpr)
+
+	return ok && !literal.Val.IsNull() && literal.Val.Type().Equals(value.Type()) && literal.Val.Equals(value).True()
+}
+
+// isNullCheck reports whether expr compares a value to null
+func isNullCheck(expr hclsyntax.Expression) bool {
+	return isNullComparison(expr, hclsyntax.OpEqual)
+}
+
+// isNotNullCheck reports whether expr checks that a value is not null
+func isNotNullCheck(expr hclsyntax.Expression) bool {
+	return isNullComparison(expr, hclsyntax.OpNotEqual)
+}
+
+func isNullComparison(expr hclsyntax.Expression, op *hclsyntax.Operation) bool {
+	comparison, ok := expr.(*hclsyntax.BinaryOpExpr)
+	if !ok || comparison.Op != op {
+		return false
+	}
+
+	return isNullLiteral(comparison.LHS) || isNullLiteral(comparison.RHS)
+}
+
+func isNullLiteral(expr hclsyntax.Expression) bool {
+	literal, ok := expr.(*hclsyntax.LiteralValueExpr)
+
+	return ok && literal.Val.IsNull()
+}
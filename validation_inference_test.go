@@ -0,0 +1,216 @@
+package tfdocextras
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func readTestVariables(t *testing.T, name string) (string, []byte) {
+	t.Helper()
+
+	filename := "testdata/" + name + "/variables.tf"
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return filename, content
+}
+
+func inferTestConstraints(t *testing.T, name string) []InferredConstraint {
+	t.Helper()
+
+	constraints, err := InferConstraints(readTestVariables(t, name))
+	if err != nil {
+		t.Fatalf("Failed to infer constraints: %v", err)
+	}
+
+	return constraints
+}
+
+func parseTestValidations(t *testing.T, name string) []VariableValidation {
+	t.Helper()
+
+	validations, err := ParseValidations(readTestVariables(t, name))
+	if err != nil {
+		t.Fatalf("Failed to parse validations: %v", err)
+	}
+
+	return validations
+}
+
+func TestInferConstraints(t *testing.T) {
+	constraints := inferTestConstraints(t, "inference")
+
+	expected := []InferredConstraint{
+		{Path: "environment", Enumerations: []string{"dev", "staging", "prod"}},
+		{Path: "bucket_name", Pattern: "^[a-z0-9.-]{3,63}$"},
+		{Path: "zone", Pattern: "^[a-z]{2}-[a-z]+-[0-9][a-z]$"},
+		{Path: "tier", Enumerations: []string{"basic", "premium", "gold"}},
+		{Path: "rules.action", Enumerations: []string{"allow", "deny"}},
+		{Path: "rules.ports", Enumerations: []string{"80", "443"}},
+	}
+
+	if diff := deep.Equal(constraints, expected); diff != nil {
+		t.Errorf("Inferred constraints mismatch:\n%v", diff)
+	}
+}
+
+func TestInferConstraints_GeneratedValidations(t *testing.T) {
+	module := loadTestModule(t, "values")
+
+	var content strings.Builder
+	for _, validation := range GenerateValidations(module.Inputs) {
+		content.WriteString("variable \"" + validation.Variable + "\" {\n" + validation.Block() + "\n}\n")
+	}
+
+	constraints, err := InferConstraints("validations_override.tf", []byte(content.String()))
+	if err != nil {
+		t.Fatalf("Failed to infer constraints: %v", err)
+	}
+
+	// The keys of maps are not fields, `@key` is not inferred
+	expected := []InferredConstraint{
+		{Path: "access_points.permissions", Enumerations: []string{"0755", "0750"}},
+		{Path: "name", Pattern: "^[a-z][a-z0-9-]*$"},
+		{Path: "performance_mode", Enumerations: []string{"generalPurpose", "maxIO"}},
+	}
+
+	if diff := deep.Equal(constraints, expected); diff != nil {
+		t.Errorf("Inferred constraints mismatch:\n%v", diff)
+	}
+}
+
+func TestParseValidations(t *testing.T) {
+	validations := parseTestValidations(t, "inference")
+
+	if len(validations) != 7 {
+		t.Fatalf("Expected 7 validations, got %d", len(validations))
+	}
+
+	expected := []VariableValidation{
+		{
+			Variable:     "bucket_name",
+			Condition:    `can(regex("^[a-z0-9.-]{3,63}$", var.bucket_name)) && length(var.bucket_name) > 3`,
+			ErrorMessage: "The bucket name is invalid.",
+		},
+		{
+			Variable:     "zone",
+			Condition:    `var.zone == null || length(regexall("^[a-z]{2}-[a-z]+-[0-9][a-z]$", var.zone)) > 0`,
+			ErrorMessage: `"The zone ${var.zone} is invalid."`,
+		},
+	}
+
+	if diff := deep.Equal(validations[1:3], expected); diff != nil {
+		t.Errorf("Validations mismatch:\n%v", diff)
+	}
+}
+
+func TestInferConstraints_Conditionals(t *testing.T) {
+	content := `
+variable "legacy" {
+  validation {
+    condition     = var.legacy == "legacy" ? true : contains(["a", "b"], var.legacy)
+    error_message = "Invalid."
+  }
+}
+
+variable "null_guard" {
+  validation {
+    condition     = var.null_guard == null ? true : contains(["a", "b"], var.null_guard)
+    error_message = "Invalid."
+  }
+}
+
+variable "not_null_guard" {
+  validation {
+    condition     = var.not_null_guard != null ? contains(["c", "d"], var.not_null_guard) : true
+    error_message = "Invalid."
+  }
+}
+`
+
+	constraints, err := InferConstraints("variables.tf", []byte(content))
+	if err != nil {
+		t.Fatalf("Failed to infer constraints: %v", err)
+	}
+
+	// The legacy value is accepted besides the values of the other branch
+	expected := []InferredConstraint{
+		{Path: "null_guard", Enumerations: []string{"a", "b"}},
+		{Path: "not_null_guard", Enumerations: []string{"c", "d"}},
+	}
+
+	if diff := deep.Equal(constraints, expected); diff != nil {
+		t.Errorf("Inferred constraints mismatch:\n%v", diff)
+	}
+}
+
+func TestInferConstraints_InvalidFile(t *testing.T) {
+	if _, err := InferConstraints("main.tf", []byte("variable \"name\" {")); err == nil {
+		t.Error("Expected an error for an invalid file")
+	}
+}
+
+func TestParseModuleInputsIntoManifest_InferredConstraints(t *testing.T) {
+	module := loadTestModule(t, "inference")
+
+	options := DefaultManifestOptions()
+	options.InferredConstraints = inferTestConstraints(t, "inference")
+	options.Validations = parseTestValidations(t, "inference")
+	manifest := ParseModuleInputsIntoManifestWithOptions(module.Inputs, options)
+
+	environment := findRow(t, manifest.RequiredInputs, "environment")
+	if diff := deep.Equal(environment.RowMetadata, RowMetadata{
+		Attributes:           []TableRowAttribute{},
+		Enumerations:         []string{"dev", "staging", "prod"},
+		Examples:             []TableRowAttribute{},
+		Links:                []TableRowAttribute{},
+		RegexConstraints:     []RegexConstraint{},
+		EnumerationsInferred: true,
+	}); diff != nil {
+		t.Errorf("Environment metadata mismatch:\n%v", diff)
+	}
+
+	zone := findRow(t, manifest.OptionalInputs, "zone")
+	if diff := deep.Equal(zone.RegexConstraints, []RegexConstraint{
+		{Pattern: "^[a-z]{2}-[a-z]+-[0-9][a-z]$", Examples: []string{}, Inferred: true},
+	}); diff != nil {
+		t.Errorf("Zone regex constraints mismatch:\n%v", diff)
+	}
+
+	// Directives take precedence over validation blocks
+	tier := findRow(t, manifest.OptionalInputs, "tier")
+	if diff := deep.Equal(tier.Enumerations, []string{"basic", "premium"}); diff != nil || tier.EnumerationsInferred {
+		t.Errorf("Tier enumerations mismatch (inferred: %t):\n%v", tier.EnumerationsInferred, diff)
+	}
+
+	action := findRow(t, manifest.NestedInputs["Rules"], "action")
+	ports := findRow(t, manifest.NestedInputs["Rules"], "ports")
+	if !action.EnumerationsInferred || !ports.EnumerationsInferred {
+		t.Errorf("Expected the enumerations of nested fields to be inferred")
+	}
+
+	if len(tier.Validations) != 1 || tier.Validations[0].ErrorMessage != "The tier is invalid." {
+		t.Errorf("Expected the validation of the tier, got %v", tier.Validations)
+	}
+
+	if diff := deep.Equal(ports.Enumerations, []string{"80", "443"}); diff != nil {
+		t.Errorf("Ports enumerations mismatch:\n%v", diff)
+	}
+
+	var mismatches []string
+	for _, diagnostic := range manifest.Diagnostics {
+		if diagnostic.Code == DiagEnumDefaultMismatch {
+			mismatches = append(mismatches, diagnostic.Path)
+		}
+	}
+
+	if diff := deep.Equal(mismatches, []string{"tier"}); diff != nil {
+		t.Errorf("Enum default mismatches:\n%v", diff)
+	}
+}
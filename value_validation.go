@@ -0,0 +1,313 @@
+package tfdocextras
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/json"
+	"github.com/terraform-docs/terraform-docs/terraform"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// Value error codes identify the kind of problem a ValueError reports
+const (
+	ValueMissingAttribute = "missing-attribute"
+	ValueUnknownAttribute = "unknown-attribute"
+	ValueTypeMismatch     = "type-mismatch"
+	ValueEnumMismatch     = "enum-mismatch"
+	ValueRegexMismatch    = "regex-mismatch"
+)
+
+// ValueError describes a problem found in a value assigned to an input of a
+// module, such as in a `.tfvars` file
+type ValueError struct {
+	Code     string `json:"code"`
+	Path     string `json:"path"`
+	Message  string `json:"message"`
+	Filename string `json:"filename,omitempty"`
+	Line     int    `json:"line,omitempty"`
+}
+
+func (e ValueError) String() string {
+	location := e.Path
+
+	if e.Filename != "" && e.Line > 0 {
+		location = fmt.Sprintf("%s:%d: %s", e.Filename, e.Line, e.Path)
+	} else if e.Filename != "" {
+		location = e.Filename + ": " + e.Path
+	}
+
+	return fmt.Sprintf("%s: %s [%s]", location, e.Message, e.Code)
+}
+
+// valueScope carries the state of the walk of a value: where its errors are
+// reported and the doc block of the nearest field, whose `@enum` and `@regex`
+// directives apply to the elements of collections as well
+type valueScope struct {
+	filename string
+	line     int
+	field    *ObjectField
+	errors   *[]ValueError
+}
+
+func (s valueScope) report(code, path, message string) {
+	*s.errors = append(*s.errors, ValueError{
+		Code:     code,
+		Path:     path,
+		Message:  message,
+		Filename: s.filename,
+		Line:     s.line,
+	})
+}
+
+// ValidateVariablesFile checks the values of a `.tfvars` file, or of a
+// `.tfvars.json` file when filename ends with ".json", against the inputs of a
+// module loaded by terraform-docs. It reports unknown variables and attributes,
+// required ones that are missing, values that cannot be converted to the
+// declared type, and values that violate the `@enum`, `@regex`, or `@key`
+// directives documenting them. An error is returned when the file is invalid.
+func ValidateVariablesFile(inputs []*terraform.Input, filename string, content []byte) ([]ValueError, error) {
+	var file *hcl.File
+	var diags hcl.Diagnostics
+
+	if strings.HasSuffix(filename, ".json") {
+		file, diags = json.Parse(content, filename)
+	} else {
+		file, diags = hclsyntax.ParseConfig(content, filename, hcl.InitialPos)
+	}
+
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	attributes, diags := file.Body.JustAttributes()
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	sorted := make([]*hcl.Attribute, 0, len(attributes))
+	for _, attribute := range attributes {
+		sorted = append(sorted, attribute)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Range.Start.Byte < sorted[j].Range.Start.Byte
+	})
+
+	errors := []ValueError{}
+
+	for _, attribute := range sorted {
+		value, diags := attribute.Expr.Value(nil)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		scope := valueScope{
+			filename: filename,
+			line:     attribute.Range.Start.Line,
+			errors:   &errors,
+		}
+
+		idx := slices.IndexFunc(inputs, func(input *terraform.Input) bool { return input.Name == attribute.Name })
+		if idx < 0 {
+			scope.report(ValueUnknownAttribute, attribute.Name, "the module has no variable named \""+attribute.Name+"\"")
+			continue
+		}
+
+		validateInputValue(inputs[idx], value, scope)
+	}
+
+	for _, input := range inputs {
+		if _, ok := attributes[input.Name]; !ok && input.Required {
+			scope := valueScope{filename: filename, errors: &errors}
+			scope.report(ValueMissingAttribute, input.Name, "the required variable is not set")
+		}
+	}
+
+	return errors, nil
+}
+
+// validateInputValue walks the value of an input along with its type and the
+// documented fields of its object type, if any
+func validateInputValue(input *terraform.Input, value cty.Value, scope valueScope) {
+	ty, _ := inputType(input)
+	field := inputField(input)
+
+	scope.field = &field
+	validateValue(input.Name, value, ty, scope)
+}
+
+// inputType returns the type constraint of an input along with the defaults of
+// its optional attributes. Inputs without a type constraint, which terraform-docs
+// reports as "any", accept any value.
+func inputType(input *terraform.Input) (cty.Type, *typeexpr.Defaults) {
+	expr, diags := hclsyntax.ParseExpression([]byte(input.Type), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return cty.DynamicPseudoType, nil
+	}
+
+	ty, defaults, diags := typeexpr.TypeConstraintWithDefaults(expr)
+	if diags.HasErrors() {
+		return cty.DynamicPseudoType, nil
+	}
+
+	return ty, defaults
+}
+
+// inputField returns the documented fields of an input, documented by the
+// input's description
+func inputField(input *terraform.Input) ObjectField {
+	field := newObjectField(input.Name)
+	if documented, err := ParseIntoDocumentedStruct(string(input.Type), input.Name); err == nil && documented != nil {
+		field = documented.ObjectField
+	}
+
+	field.Documentation = parseStringIntoDocBlock(string(input.Description))
+	if isMapTypeStr(string(input.Type)) {
+		field.MapKey = parseMapKey(field.Documentation.Directives)
+	}
+
+	return field
+}
+
+func validateValue(path string, value cty.Value, ty cty.Type, scope valueScope) {
+	if value.IsNull() || !value.IsWhollyKnown() || ty.Equals(cty.DynamicPseudoType) {
+		return
+	}
+
+	valueType := value.Type()
+
+	switch {
+	case ty.IsObjectType():
+		if !valueType.IsObjectType() {
+			break
+		}
+
+		names := slices.Sorted(maps.Keys(ty.AttributeTypes()))
+		for name := range valueType.AttributeTypes() {
+			if !ty.HasAttribute(name) {
+				names = append(names, name)
+			}
+		}
+
+		slices.Sort(names)
+
+		for _, name := range names {
+			attributePath := path + "." + name
+
+			switch {
+			case !ty.HasAttribute(name):
+				scope.report(ValueUnknownAttribute, attributePath, "the type has no attribute named \""+name+"\"")
+			case !valueType.HasAttribute(name):
+				if !ty.AttributeOptional(name) {
+					scope.report(ValueMissingAttribute, attributePath, "the required attribute is not set")
+				}
+			default:
+				validateValue(attributePath, value.GetAttr(name), ty.AttributeType(name), scope.child(name))
+			}
+		}
+
+		return
+
+	case ty.IsListType() || ty.IsSetType():
+		if !valueType.IsTupleType() {
+			break
+		}
+
+		for it := value.ElementIterator(); it.Next(); {
+			key, element := it.Element()
+			validateValue(path+"["+key.AsBigFloat().String()+"]", element, ty.ElementType(), scope)
+		}
+
+		return
+
+	case ty.IsMapType():
+		if !valueType.IsObjectType() {
+			break
+		}
+
+		for it := value.ElementIterator(); it.Next(); {
+			key, element := it.Element()
+			elementPath := path + "[" + strconv.Quote(key.AsString()) + "]"
+
+			if scope.field != nil && scope.field.MapKey != nil && scope.field.MapKey.Pattern != "" {
+				if !regexp.MustCompile(scope.field.MapKey.Pattern).MatchString(key.AsString()) {
+					scope.report(ValueRegexMismatch, elementPath, "the key does not match /"+scope.field.MapKey.Pattern+"/")
+				}
+			}
+
+			validateValue(elementPath, element, ty.ElementType(), scope)
+		}
+
+		return
+
+	case ty.IsTupleType():
+		// Tuples are rare in variable types, only check that the value converts
+		if _, err := convert.Convert(value, ty); err != nil {
+			break
+		}
+
+		return
+
+	case ty.IsPrimitiveType():
+		converted, err := convert.Convert(value, ty)
+		if err != nil {
+			break
+		}
+
+		validateDirectives(path, converted, scope)
+
+		return
+	}
+
+	scope.report(ValueTypeMismatch, path, "expected "+typeexpr.TypeString(ty)+", got "+valueType.FriendlyName())
+}
+
+// validateDirectives checks a primitive value against the `@enum` and `@regex`
+// directives of the nearest field
+func validateDirectives(path string, value cty.Value, scope valueScope) {
+	if scope.field == nil {
+		return
+	}
+
+	str, err := convert.Convert(value, cty.String)
+	if err != nil {
+		return
+	}
+
+	for _, attr := range scope.field.Documentation.Directives {
+		if (attr.Parsed.Flags & IsValid) == 0 {
+			continue
+		}
+
+		switch attr.Parsed.Type {
+		case DirEnum:
+			allowed := slices.Contains(enumValues(attr.Parsed.Args), str.AsString())
+
+			if !allowed {
+				scope.report(ValueEnumMismatch, path, "\""+str.AsString()+"\" is not one of the @enum values "+strings.Join(attr.Parsed.Args, " | "))
+			}
+		case DirRegex:
+			if value.Type() == cty.String && !regexp.MustCompile(attr.Parsed.Args[0]).MatchString(str.AsString()) {
+				scope.report(ValueRegexMismatch, path, "\""+str.AsString()+"\" does not match /"+attr.Parsed.Args[0]+"/")
+			}
+		}
+	}
+}
+
+// child returns the scope of the attribute called name of an object
+func (s valueScope) child(name string) valueScope {
+	child := s
+	child.field = s.field.child(name)
+
+	return child
+}
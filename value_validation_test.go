@@ -0,0 +1,63 @@
+package tfdocextras
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func validateTestFile(t *testing.T, filename string) []ValueError {
+	t.Helper()
+
+	module := loadTestModule(t, "values")
+
+	content, err := os.ReadFile("testdata/values/" + filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errors, err := ValidateVariablesFile(module.Inputs, filename, content)
+	if err != nil {
+		t.Fatalf("Failed to validate %s: %v", filename, err)
+	}
+
+	return errors
+}
+
+func TestValidateVariablesFile(t *testing.T) {
+	var actual []string
+	for _, valueErr := range validateTestFile(t, "invalid.tfvars") {
+		actual = append(actual, valueErr.String())
+	}
+
+	expected := []string{
+		`invalid.tfvars:1: performance_mode: "bursting" is not one of the @enum values generalPurpose | maxIO [enum-mismatch]`,
+		`invalid.tfvars:3: access_points["Admin"]: the key does not match /^[a-z-]+$/ [regex-mismatch]`,
+		`invalid.tfvars:3: access_points["Admin"].owner: the type has no attribute named "owner" [unknown-attribute]`,
+		`invalid.tfvars:3: access_points["Admin"].user.uid: expected number, got string [type-mismatch]`,
+		`invalid.tfvars:3: access_points["shared"].permissions: "0777" is not one of the @enum values 0755 | 0750 [enum-mismatch]`,
+		`invalid.tfvars:3: access_points["shared"].user.gid: the required attribute is not set [missing-attribute]`,
+		`invalid.tfvars:15: subnet_ids: expected list(string), got string [type-mismatch]`,
+		`invalid.tfvars:17: throughput_mode: the module has no variable named "throughput_mode" [unknown-attribute]`,
+		`invalid.tfvars: name: the required variable is not set [missing-attribute]`,
+	}
+
+	if diff := deep.Equal(actual, expected); diff != nil {
+		t.Errorf("Errors mismatch:\n%v", diff)
+	}
+}
+
+func TestValidateVariablesFile_JSON(t *testing.T) {
+	if errors := validateTestFile(t, "valid.tfvars.json"); len(errors) != 0 {
+		t.Errorf("Expected no errors, got %v", errors)
+	}
+}
+
+func TestValidateVariablesFile_SyntaxError(t *testing.T) {
+	module := loadTestModule(t, "values")
+
+	if _, err := ValidateVariablesFile(module.Inputs, "broken.tfvars", []byte("name = ")); err == nil {
+		t.Error("Expected a syntax error")
+	}
+}